@@ -5,13 +5,27 @@ import (
 	"elastic-proximity-calculation/src/elastic"
 	"elastic-proximity-calculation/src/helpers"
 	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/numbers"
+	"elastic-proximity-calculation/src/query"
+	"elastic-proximity-calculation/src/structs"
+	"elastic-proximity-calculation/src/tokenizer"
 	"flag"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	commandCalculate = "calculate"
+	commandQuery     = "query"
+	commandServe     = "serve"
+)
+
 var (
+	command string
+
 	Scheme       string
 	Address      string
 	Port         string
@@ -19,18 +33,53 @@ var (
 	Password     string
 	LoggerEnable bool
 
-	proximityAmbit       int
+	searchTarget         query.Target
+	windows              []calculator.Window
+	fieldWindows         map[string]calculator.Window
+	languageWindows      map[string]calculator.Window
+	windowStrategy       string
+	anchorTerms          []string
+	anchorPatterns       []calculator.AnchorPattern
+	dateMode             string
+	noiseRules           []calculator.NoiseRule
+	layout               string
+	indexShards          int
+	indexReplicas        int
+	languageAnalyzers    map[string]string
+	rebuild              bool
+	rebuildDeleteOld     bool
+	precision            int
 	keepAlive            int
 	sourceIndex          string
 	proximityIndexPrefix string
 	pageSize             int
 	uploadChunkSize      int
 	logDirectory         string
+	tokenizerName        string
+	languageTokenizers   map[string]string
+	cjkDictionariesRaw   string
+	boundary             string
+	fieldBoundaries      map[string]string
 
 	config calculator.Config
 )
 
+// parseCommand Функция извлекает из аргументов командной строки подкоманду (calculate, query, serve).
+// Если подкоманда не указана, используется calculate
+func parseCommand() string {
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		cmd := os.Args[1]
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+
+		return cmd
+	}
+
+	return commandCalculate
+}
+
 func initWithFlags() {
+	command = parseCommand()
+
 	SchemeEnv := helpers.Env("ELASTIC_SCHEME", "http")
 	flag.StringVar(&Scheme, "ELASTIC_SCHEME", SchemeEnv, "HTTP-схема для подключения к Elasticsearch.")
 
@@ -55,8 +104,27 @@ func initWithFlags() {
 	logDirectoryEnv := helpers.Env("LOG_DIRECTORY", "")
 	flag.StringVar(&logDirectory, "LOG_DIRECTORY", logDirectoryEnv, "Папка для хранения логов. По умолчанию папка исполнения.")
 
-	proximityAmbitEnv, _ := strconv.Atoi(helpers.Env("PROXIMITY_AMBIT", "15"))
-	flag.IntVar(&proximityAmbit, "PROXIMITY_AMBIT", proximityAmbitEnv, "Размерность окрестности.")
+	var proximityAmbitRaw string
+	proximityAmbitEnv := helpers.Env("PROXIMITY_AMBIT", "15")
+	flag.StringVar(&proximityAmbitRaw, "PROXIMITY_AMBIT", proximityAmbitEnv, "Размерность окрестности. Допускается список через запятую, например: 5,15,30 - окрестности всех размерностей вычисляются за один проход.")
+
+	precisionEnv, _ := strconv.Atoi(helpers.Env("PRECISION", "5"))
+	flag.IntVar(&precision, "PRECISION", precisionEnv, "Количество знаков после запятой, до которого округляются числа.")
+
+	var windowRaw string
+	windowEnv := helpers.Env("PROXIMITY_WINDOW", "")
+	flag.StringVar(&windowRaw, "PROXIMITY_WINDOW", windowEnv, "Несимметричная размерность окрестности в формате слева:справа, например: 5:15. Допускается список через запятую. По умолчанию -PROXIMITY_AMBIT с обеих сторон.")
+
+	windowStrategyEnv := helpers.Env("WINDOW_STRATEGY", structs.StrategyTokens)
+	flag.StringVar(&windowStrategy, "WINDOW_STRATEGY", windowStrategyEnv, "Способ подсчета размерности окрестности: "+strings.Join(structs.WindowStrategies, ", ")+".")
+
+	var fieldWindowsRaw string
+	fieldWindowsEnv := helpers.Env("FIELD_WINDOWS", "")
+	flag.StringVar(&fieldWindowsRaw, "FIELD_WINDOWS", fieldWindowsEnv, "Размерности окрестности для отдельных полей в формате поле=размерность через запятую, например: claims_cleaned=5,description_cleaned=10:30.")
+
+	var languageWindowsRaw string
+	languageWindowsEnv := helpers.Env("LANGUAGE_WINDOWS", "")
+	flag.StringVar(&languageWindowsRaw, "LANGUAGE_WINDOWS", languageWindowsEnv, "Размерности окрестности для отдельных языков в формате язык=размерность через запятую.")
 
 	keepAliveEnv, _ := strconv.Atoi(helpers.Env("SCROLL_KEEP_ALIVE", "5"))
 	flag.IntVar(&keepAlive, "SCROLL_KEEP_ALIVE", keepAliveEnv, "Срок жизни токена для Scroll API в минутах.")
@@ -67,12 +135,229 @@ func initWithFlags() {
 	uploadChunkSizeEnv, _ := strconv.Atoi(helpers.Env("UPLOAD_CHUNK_SIZE", "1000000"))
 	flag.IntVar(&uploadChunkSize, "UPLOAD_CHUNK_SIZE", uploadChunkSizeEnv, "Размерность буффера для хранения готовых для отправки окрестностей. Данный параметр влияет на потребление ОЗУ!")
 
+	tokenizerEnv := helpers.Env("TOKENIZER", tokenizer.NameRegex)
+	flag.StringVar(&tokenizerName, "TOKENIZER", tokenizerEnv, "Токенизатор по умолчанию. Доступны: "+strings.Join(tokenizer.Names(), ", ")+".")
+
+	var languageTokenizersRaw string
+	languageTokenizersEnv := helpers.Env("LANGUAGE_TOKENIZERS", "zh=cjk,ja=cjk,ko=cjk")
+	flag.StringVar(&languageTokenizersRaw, "LANGUAGE_TOKENIZERS", languageTokenizersEnv, "Токенизаторы для отдельных языков в формате язык=токенизатор через запятую.")
+
+	cjkDictionariesEnv := helpers.Env("CJK_DICTIONARIES", "")
+	flag.StringVar(&cjkDictionariesRaw, "CJK_DICTIONARIES", cjkDictionariesEnv, "Частотные словари токенизатора cjk в формате язык=путь к файлу через запятую, например: zh=/data/jieba/dict.txt. Заменяют встроенные словари языков.")
+
+	boundaryEnv := helpers.Env("BOUNDARY", calculator.BoundaryNone)
+	flag.StringVar(&boundary, "BOUNDARY", boundaryEnv, "Граница окрестности: "+strings.Join(calculator.BoundaryModes, ", ")+". Окрестность числа не выходит за пределы предложения, абзаца или пункта формулы.")
+
+	var fieldBoundariesRaw string
+	fieldBoundariesEnv := helpers.Env("FIELD_BOUNDARIES", "")
+	flag.StringVar(&fieldBoundariesRaw, "FIELD_BOUNDARIES", fieldBoundariesEnv, "Границы окрестности для отдельных полей в формате поле=граница через запятую, например: claims_cleaned=claim.")
+
+	var anchorTermsRaw string
+	anchorTermsEnv := helpers.Env("ANCHOR_TERMS", "")
+	flag.StringVar(&anchorTermsRaw, "ANCHOR_TERMS", anchorTermsEnv, "Термины-якоря через запятую, например: pressure,viscosity,melting point. Вокруг каждого термина строится окрестность со списком чисел.")
+
+	var anchorTermsFile string
+	anchorTermsFileEnv := helpers.Env("ANCHOR_TERMS_FILE", "")
+	flag.StringVar(&anchorTermsFile, "ANCHOR_TERMS_FILE", anchorTermsFileEnv, "Файл со словарем терминов-якорей: один термин в строке, строки с # игнорируются.")
+
+	var anchorPatternsFile string
+	anchorPatternsFileEnv := helpers.Env("ANCHOR_PATTERNS_FILE", "")
+	flag.StringVar(&anchorPatternsFile, "ANCHOR_PATTERNS_FILE", anchorPatternsFileEnv, "Файл с именованными регулярными выражениями-якорями: в строке имя и выражение через пробел, например: standard \\bISO ?[0-9]+\\b.")
+
+	dateModeEnv := helpers.Env("DATE_MODE", calculator.DateModeNone)
+	flag.StringVar(&dateMode, "DATE_MODE", dateModeEnv, "Распознавание дат: "+strings.Join(calculator.DateModes, ", ")+". В режиме tag год даты сохраняется в num, в режиме suppress дата не участвует в поиске чисел.")
+
+	var noiseRulesRaw string
+	noiseRulesEnv := helpers.Env("NOISE_RULES", "")
+	flag.StringVar(&noiseRulesRaw, "NOISE_RULES", noiseRulesEnv, "Встроенные правила распознавания шумовых чисел в формате правило=действие через запятую, например: figure=drop,claim=drop,element=tag. Правила: "+strings.Join(calculator.NoiseRuleNames(), ", ")+"; действия: "+calculator.NoiseDrop+", "+calculator.NoiseTag+".")
+
+	var noiseRulesFile string
+	noiseRulesFileEnv := helpers.Env("NOISE_RULES_FILE", "")
+	flag.StringVar(&noiseRulesFile, "NOISE_RULES_FILE", noiseRulesFileEnv, "Файл с пользовательскими правилами распознавания шумовых чисел: в строке имя, действие, вид (regex, before, after) и значение через пробел.")
+
+	layoutEnv := helpers.Env("OUTPUT_LAYOUT", structs.LayoutFlat)
+	flag.StringVar(&layout, "OUTPUT_LAYOUT", layoutEnv, "Формат записи соседей в индексе окрестностей: "+strings.Join(structs.Layouts, ", ")+". В формате nested соседи хранятся в nested-массиве neighbours, в формате positions - в текстовом поле context для запросов span_near и match_phrase.")
+
+	indexShardsEnv, _ := strconv.Atoi(helpers.Env("INDEX_SHARDS", "1"))
+	flag.IntVar(&indexShards, "INDEX_SHARDS", indexShardsEnv, "Количество основных шардов индексов окрестностей (задается в шаблоне индекса).")
+
+	indexReplicasEnv, _ := strconv.Atoi(helpers.Env("INDEX_REPLICAS", "1"))
+	flag.IntVar(&indexReplicas, "INDEX_REPLICAS", indexReplicasEnv, "Количество реплик индексов окрестностей (задается в шаблоне индекса).")
+
+	var languageAnalyzersRaw string
+	languageAnalyzersEnv := helpers.Env("LANGUAGE_ANALYZERS", "en=english,ru=russian,de=german,fr=french,es=spanish,it=italian,pt=portuguese,zh=cjk,ja=cjk,ko=cjk")
+	flag.StringVar(&languageAnalyzersRaw, "LANGUAGE_ANALYZERS", languageAnalyzersEnv, "Анализаторы Elasticsearch для токенов соседей в индексах отдельных языков в формате язык=анализатор через запятую. Для остальных языков используется анализатор standard.")
+
+	rebuildEnv, _ := strconv.ParseBool(helpers.Env("REBUILD", "false"))
+	flag.BoolVar(&rebuild, "REBUILD", rebuildEnv, "Пересборка индексов окрестностей: запись в новые индексы <имя>_<время запуска>, проверка количества документов и атомарное переключение псевдонимов <имя>.")
+
+	rebuildDeleteOldEnv, _ := strconv.ParseBool(helpers.Env("REBUILD_DELETE_OLD", "false"))
+	flag.BoolVar(&rebuildDeleteOld, "REBUILD_DELETE_OLD", rebuildDeleteOldEnv, "[REBUILD] Удалять индексы старого поколения после переключения псевдонимов.")
+
 	flag.BoolVar(&LoggerEnable, "ELASTIC_DEBUG_REQUESTS", false, "Параметр для активации логгера для каждого отдельного запроса в Elasticsearch.")
 
+	initQueryFlags()
+	initServeFlags()
+
 	flag.Parse()
 
 	logger.InitLogger(logDirectory)
 
+	var err error
+	if languageAnalyzers, err = helpers.ParseMap(languageAnalyzersRaw); err != nil {
+		logger.Error("Некорректный параметр -LANGUAGE_ANALYZERS: %s", err.Error())
+	}
+
+	if indexShards < 1 || indexReplicas < 0 {
+		logger.Error("Некорректное количество шардов или реплик: %s", strconv.Itoa(indexShards)+"/"+strconv.Itoa(indexReplicas))
+	}
+
+	if languageTokenizers, err = helpers.ParseMap(languageTokenizersRaw); err != nil {
+		logger.Error("Некорректный параметр -LANGUAGE_TOKENIZERS: %s", err.Error())
+	}
+
+	for _, name := range append([]string{tokenizerName}, mapValues(languageTokenizers)...) {
+		if !tokenizer.Exists(name) {
+			logger.Error("Неизвестный токенизатор: %s", name)
+		}
+	}
+
+	cjkDictionaries, err := helpers.ParseMap(cjkDictionariesRaw)
+	if err != nil {
+		logger.Error("Некорректный параметр -CJK_DICTIONARIES: %s", err.Error())
+	}
+
+	if command == commandCalculate {
+		for language, path := range cjkDictionaries {
+			if err := tokenizer.LoadDictionary(language, path); err != nil {
+				logger.Error("Не удалось загрузить словарь языка "+language+": %s", err.Error())
+			}
+		}
+
+		for language, name := range languageTokenizers {
+			if name == tokenizer.NameCJK && cjkDictionaries[language] == "" {
+				logger.Warning("Для языка %s используется встроенный словарь токенизатора cjk: он содержит только частые слова и термины, для реальных текстов укажите полный словарь в -CJK_DICTIONARIES", language)
+			}
+		}
+	}
+
+	if windows, err = calculator.ParseWindowList(proximityAmbitRaw); err != nil {
+		logger.Error("Некорректный параметр -PROXIMITY_AMBIT: %s", err.Error())
+	}
+
+	if windowRaw != "" {
+		if windows, err = calculator.ParseWindowList(windowRaw); err != nil {
+			logger.Error("Некорректный параметр -PROXIMITY_WINDOW: %s", err.Error())
+		}
+	}
+
+	if precision < 0 || precision > 15 {
+		logger.Error("Количество знаков после запятой -PRECISION должно быть в пределах [0..15], указано: %s", strconv.Itoa(precision))
+	}
+	numbers.Precision = precision
+
+	if _, ok := structs.GetEncoder(layout); !ok {
+		logger.Error("Неизвестный формат записи соседей: %s", layout)
+	}
+	query.Layout = layout
+
+	if !calculator.IsDateMode(dateMode) {
+		logger.Error("Неизвестный режим распознавания дат: %s", dateMode)
+	}
+
+	if !structs.IsWindowStrategy(windowStrategy) {
+		logger.Error("Неизвестный способ подсчета размерности окрестности: %s", windowStrategy)
+	}
+	query.Strategy = windowStrategy
+
+	if fieldWindows, err = parseWindows(fieldWindowsRaw); err != nil {
+		logger.Error("Некорректный параметр -FIELD_WINDOWS: %s", err.Error())
+	}
+
+	if languageWindows, err = parseWindows(languageWindowsRaw); err != nil {
+		logger.Error("Некорректный параметр -LANGUAGE_WINDOWS: %s", err.Error())
+	}
+
+	searchTarget = query.Target{
+		Prefix:          proximityIndexPrefix,
+		Window:          maxWindow(windows),
+		FieldWindows:    searchWindows(fieldWindows),
+		LanguageWindows: searchWindows(languageWindows),
+	}
+
+	anchorTerms = helpers.ParseList(anchorTermsRaw)
+	if anchorTermsFile != "" {
+		terms, err := readLines(anchorTermsFile)
+		if err != nil {
+			logger.Error("Не удалось прочитать словарь терминов -ANCHOR_TERMS_FILE: %s", err.Error())
+		}
+
+		anchorTerms = append(anchorTerms, terms...)
+	}
+
+	if anchorPatternsFile != "" {
+		lines, err := readLines(anchorPatternsFile)
+		if err != nil {
+			logger.Error("Не удалось прочитать файл -ANCHOR_PATTERNS_FILE: %s", err.Error())
+		}
+
+		for _, line := range lines {
+			anchorPattern, err := calculator.ParseAnchorPattern(line)
+			if err != nil {
+				logger.Error("Некорректное выражение в -ANCHOR_PATTERNS_FILE: %s", err.Error())
+			}
+
+			anchorPatterns = append(anchorPatterns, anchorPattern)
+		}
+	}
+
+	noiseRulesMap, err := helpers.ParseMap(noiseRulesRaw)
+	if err != nil {
+		logger.Error("Некорректный параметр -NOISE_RULES: %s", err.Error())
+	}
+
+	for _, name := range calculator.NoiseRuleNames() {
+		if action, ok := noiseRulesMap[name]; ok {
+			noiseRule, err := calculator.BuiltinNoiseRule(name, action)
+			if err != nil {
+				logger.Error("Некорректный параметр -NOISE_RULES: %s", err.Error())
+			}
+
+			noiseRules = append(noiseRules, noiseRule)
+			delete(noiseRulesMap, name)
+		}
+	}
+
+	for name := range noiseRulesMap {
+		logger.Error("Некорректный параметр -NOISE_RULES: неизвестное правило %s", name)
+	}
+
+	if noiseRulesFile != "" {
+		lines, err := readLines(noiseRulesFile)
+		if err != nil {
+			logger.Error("Не удалось прочитать файл -NOISE_RULES_FILE: %s", err.Error())
+		}
+
+		for _, line := range lines {
+			noiseRule, err := calculator.ParseNoiseRule(line)
+			if err != nil {
+				logger.Error("Некорректное правило в -NOISE_RULES_FILE: %s", err.Error())
+			}
+
+			noiseRules = append(noiseRules, noiseRule)
+		}
+	}
+
+	if fieldBoundaries, err = helpers.ParseMap(fieldBoundariesRaw); err != nil {
+		logger.Error("Некорректный параметр -FIELD_BOUNDARIES: %s", err.Error())
+	}
+
+	for _, mode := range append([]string{boundary}, mapValues(fieldBoundaries)...) {
+		if !calculator.IsBoundaryMode(mode) {
+			logger.Error("Неизвестная граница окрестности: %s", mode)
+		}
+	}
+
 	if Username != "" && Password == "" {
 		logger.Error("Указан пользователь, но не указан пароль. Используйте -ELASTIC_PASSWORD=...")
 	}
@@ -81,15 +366,93 @@ func initWithFlags() {
 		logger.Error("Указан пароль, но не указан пользователь. Используйте -ELASTIC_USERNAME=...")
 	}
 
-	if sourceIndex == "" {
-		logger.Error("Не указан индекс источник. Используйте -SOURCE_INDEX=...")
+	switch command {
+	case commandCalculate:
+		if sourceIndex == "" {
+			logger.Error("Не указан индекс источник. Используйте -SOURCE_INDEX=...")
+		}
+	case commandQuery, commandServe:
+	default:
+		logger.Error("Неизвестная команда: %s. Допустимые команды: "+commandCalculate+", "+commandQuery+", "+commandServe, command)
 	}
 
-	if sourceIndex == "" {
+	if proximityIndexPrefix == "" {
 		logger.Error("Не указан префикс для таргетного индекса. Используйте -TARGET_INDEX_PREFIX=...")
 	}
 }
 
+func mapValues(m map[string]string) []string {
+	var values []string
+	for _, value := range m {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// readLines Функция читает файл построчно, пустые строки и строки с # пропускаются
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// maxWindow Функция возвращает размерность окрестности с наибольшим расстоянием с любой стороны от числа: по ее индексам выполняется поиск.
+// При равном расстоянии выбирается окрестность, охватывающая больше токенов
+func maxWindow(windows []calculator.Window) query.Window {
+	var max query.Window
+	for _, window := range windows {
+		current := query.Window{Left: window.Left, Right: window.Right}
+		if current.Ambit() > max.Ambit() || (current.Ambit() == max.Ambit() && current.Left+current.Right > max.Left+max.Right) {
+			max = current
+		}
+	}
+
+	return max
+}
+
+// searchWindows Функция переводит размерности окрестности полей или языков в размерности поиска
+func searchWindows(windows map[string]calculator.Window) map[string]query.Window {
+	result := map[string]query.Window{}
+	for key, window := range windows {
+		result[key] = query.Window{Left: window.Left, Right: window.Right}
+	}
+
+	return result
+}
+
+// parseWindows Функция для разбора размерностей окрестности вида "ключ=размерность,ключ2=слева:справа"
+func parseWindows(s string) (map[string]calculator.Window, error) {
+	m, err := helpers.ParseMap(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculator.ParseWindows(m)
+}
+
+func windowsString(windows []calculator.Window) string {
+	var values []string
+	for _, window := range windows {
+		values = append(values, window.String())
+	}
+
+	return strings.Join(values, ", ")
+}
+
 func init() {
 	startTime := time.Now()
 
@@ -104,25 +467,46 @@ func init() {
 			Password:     Password,
 			LoggerEnable: LoggerEnable,
 		},
-		ProximityAmbit:       proximityAmbit,
+		Windows:              windows,
+		FieldWindows:         fieldWindows,
+		LanguageWindows:      languageWindows,
+		WindowStrategy:       windowStrategy,
+		AnchorTerms:          anchorTerms,
+		AnchorPatterns:       anchorPatterns,
+		DateMode:             dateMode,
+		NoiseRules:           noiseRules,
+		Layout:               layout,
+		IndexShards:          indexShards,
+		IndexReplicas:        indexReplicas,
+		LanguageAnalyzers:    languageAnalyzers,
+		Rebuild:              rebuild,
+		RebuildDeleteOld:     rebuildDeleteOld,
 		KeepAlive:            keepAlive,
 		SourceIndex:          sourceIndex,
 		ProximityIndexPrefix: proximityIndexPrefix,
 		PageSize:             pageSize,
 		UploadChunkSize:      uploadChunkSize,
+		Tokenizer:            tokenizerName,
+		LanguageTokenizers:   languageTokenizers,
+		Boundary:             boundary,
+		FieldBoundaries:      fieldBoundaries,
 		Start:                startTime,
 	}
 
+	if command != commandCalculate {
+		return
+	}
+
 	if Username != "" && Password != "" {
 		logger.Info(
 			fmt.Sprintf(
-				"---- Параметры:\n\nElasitcsearch: %s://%s:%s [username: %s, password: %s]\nРазмерность окрестности: %d\nВремя жизни токена Scroll API (в минутах): %d\nИндекс источник: %s\nПрефикс таргетного индекса: %s\nРазмер одной страницы для Scroll API: %d\nРазмерность буффера для хранения готовых для отправки окрестностей: %d\n",
+				"---- Параметры:\n\nElasitcsearch: %s://%s:%s [username: %s, password: %s]\nРазмерность окрестности: %s\nВремя жизни токена Scroll API (в минутах): %d\nИндекс источник: %s\nПрефикс таргетного индекса: %s\nРазмер одной страницы для Scroll API: %d\nРазмерность буффера для хранения готовых для отправки окрестностей: %d\n",
 				Scheme,
 				Address,
 				Port,
 				Username,
 				Password,
-				proximityAmbit,
+				windowsString(windows),
 				keepAlive,
 				sourceIndex,
 				proximityIndexPrefix,
@@ -133,11 +517,11 @@ func init() {
 	} else {
 		logger.Info(
 			fmt.Sprintf(
-				"---- Параметры:\n\nElasitcsearch: %s://%s:%s\nРазмерность окрестности: %d\nВремя жизни токена Scroll API (в минутах): %d\nИндекс источник: %s\nПрефикс таргетного индекса: %s\nРазмер одной страницы для Scroll API: %d\nРазмерность буффера для хранения готовых для отправки окрестностей: %d\n",
+				"---- Параметры:\n\nElasitcsearch: %s://%s:%s\nРазмерность окрестности: %s\nВремя жизни токена Scroll API (в минутах): %d\nИндекс источник: %s\nПрефикс таргетного индекса: %s\nРазмер одной страницы для Scroll API: %d\nРазмерность буффера для хранения готовых для отправки окрестностей: %d\n",
 				Scheme,
 				Address,
 				Port,
-				proximityAmbit,
+				windowsString(windows),
 				keepAlive,
 				sourceIndex,
 				proximityIndexPrefix,
@@ -149,9 +533,16 @@ func init() {
 }
 
 func main() {
-	logger.Info("Начало работы")
+	switch command {
+	case commandQuery:
+		runQuery()
+	case commandServe:
+		runServe()
+	default:
+		logger.Info("Начало работы")
 
-	calculator.Do(config)
+		calculator.Do(config)
 
-	logger.Info("Конец работы")
+		logger.Info("Конец работы")
+	}
 }
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/query"
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+var (
+	queryExpression string
+	queryWord       string
+	queryMin        string
+	queryMax        string
+	queryUnit       string
+	queryDistance   int
+	queryDirection  string
+	queryLanguage   string
+	querySize       int
+)
+
+func initQueryFlags() {
+	flag.StringVar(&queryExpression, "QUERY", "", "[query] Выражение на языке запросов, например: temperature NEAR/5 [100..200]. Если указано, параметры -WORD, -RANGE_*, -DISTANCE и -DIRECTION игнорируются.")
+	flag.StringVar(&queryWord, "WORD", "", "[query] Слово, рядом с которым ищется число.")
+	flag.StringVar(&queryMin, "RANGE_MIN", "", "[query] Нижняя граница диапазона (включительно). По умолчанию не ограничена.")
+	flag.StringVar(&queryMax, "RANGE_MAX", "", "[query] Верхняя граница диапазона (включительно). По умолчанию не ограничена.")
+	flag.StringVar(&queryUnit, "UNIT", "", "[query] Единица измерения границ диапазона (mm, °C, bar, %, кг, ...). Если указана, сравнение идет в СИ.")
+	flag.IntVar(&queryDistance, "DISTANCE", 5, "[query] Максимальное расстояние между словом и числом в токенах.")
+	flag.StringVar(&queryDirection, "DIRECTION", query.DirectionAny, "[query] Положение слова относительно числа: any, before, after.")
+	flag.StringVar(&queryLanguage, "LANGUAGE", "", "[query] Язык индекса окрестностей. По умолчанию поиск по всем языкам.")
+	flag.IntVar(&querySize, "RESULT_SIZE", 10, "[query] Максимальное количество найденных окрестностей.")
+}
+
+// parseBound Функция для разбора границы диапазона. Пустая строка означает отсутствие границы
+func parseBound(name string, value string) *float64 {
+	if value == "" {
+		return nil
+	}
+
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Error("Некорректная граница диапазона -"+name+": %s", value)
+	}
+
+	return &num
+}
+
+func runQuery() {
+	request := query.Request{
+		Expression: queryExpression,
+		Word:       queryWord,
+		Min:        parseBound("RANGE_MIN", queryMin),
+		Max:        parseBound("RANGE_MAX", queryMax),
+		Unit:       queryUnit,
+		Distance:   queryDistance,
+		Direction:  queryDirection,
+		Language:   queryLanguage,
+		Size:       querySize,
+	}
+
+	client := elastic.GetElasticsearchClient(config.Elastic)
+
+	hits, err := query.Search(client, searchTarget, request)
+	if err != nil {
+		logger.Error("Ошибка при выполнении поиска: %s", err.Error())
+	}
+
+	logger.Info("Найдено окрестностей: %s", strconv.Itoa(len(hits)))
+
+	for _, hit := range hits {
+		num := hit.Expression
+		if num == "" {
+			num = strconv.FormatFloat(hit.Num, 'f', -1, 64)
+		}
+
+		fmt.Printf("%s\t%s\t%s\t%d\t%s\n", hit.SourceId, hit.SourceField, num, hit.Offset, hit.Context)
+	}
+}
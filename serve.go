@@ -0,0 +1,29 @@
+package main
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/helpers"
+	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/server"
+	"flag"
+)
+
+var serverAddress string
+
+func initServeFlags() {
+	serverAddressEnv := helpers.Env("SERVER_ADDRESS", ":8080")
+	flag.StringVar(&serverAddress, "SERVER_ADDRESS", serverAddressEnv, "[serve] Адрес, на котором HTTP-сервис поиска принимает запросы.")
+}
+
+func runServe() {
+	client := elastic.GetElasticsearchClient(config.Elastic)
+
+	err := server.Serve(client, server.Config{
+		Address: serverAddress,
+		Target:  searchTarget,
+	})
+
+	if err != nil {
+		logger.Error("HTTP-сервис остановлен с ошибкой: %s", err.Error())
+	}
+}
@@ -0,0 +1,133 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"elastic-proximity-calculation/src/tokenizer"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TypeAnchor Тип токена, объединяющего именованное выражение (см. AnchorPattern)
+const TypeAnchor = "anchor"
+
+// AnchorPattern Именованное регулярное выражение, совпадения с которым становятся центрами окрестностей,
+// например chemical - химические формулы (SiO2), standard - стандарты (ISO 9001)
+type AnchorPattern struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// ParseAnchorPattern Функция для разбора строки вида "имя регулярное_выражение"
+func ParseAnchorPattern(line string) (AnchorPattern, error) {
+	parts := strings.SplitN(strings.TrimSpace(line), " ", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return AnchorPattern{}, errors.New("ожидалась строка вида \"имя регулярное_выражение\", получено: " + line)
+	}
+
+	pattern, err := regexp.Compile(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return AnchorPattern{}, errors.New(parts[0] + ": " + err.Error())
+	}
+
+	return AnchorPattern{Name: parts[0], Pattern: pattern}, nil
+}
+
+// anchorMatch Совпадение именованного выражения в тексте
+type anchorMatch struct {
+	name  string
+	start int
+	end   int
+	// date Дата в формате ISO 8601 для совпадений типа AnchorTypeDate
+	date string
+}
+
+// mergeAnchors Функция объединяет токены, покрытые совпадениями именованных выражений и датами, в один токен типа TypeAnchor.
+// Совпадение расширяется до границ затронутых токенов. Из пересекающихся совпадений остается то, что начинается раньше
+// (при равном начале - более длинное)
+func mergeAnchors(text string, tokens []token, language string) []token {
+	var matches []anchorMatch
+	if config.DateMode != "" && config.DateMode != DateModeNone {
+		matches = findDates(text, language)
+	}
+
+	for _, anchorPattern := range config.AnchorPatterns {
+		for _, location := range anchorPattern.Pattern.FindAllStringIndex(text, -1) {
+			if location[1] > location[0] {
+				matches = append(matches, anchorMatch{name: anchorPattern.Name, start: location[0], end: location[1]})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return tokens
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].start != matches[b].start {
+			return matches[a].start < matches[b].start
+		}
+
+		return matches[a].end > matches[b].end
+	})
+
+	result := make([]token, 0, len(tokens))
+	i := 0
+
+	for _, match := range matches {
+		for i < len(tokens) && tokens[i].End <= match.start {
+			result = append(result, tokens[i])
+			i++
+		}
+
+		// совпадение начинается внутри уже объединенного токена
+		if len(result) > 0 && result[len(result)-1].End > match.start {
+			continue
+		}
+
+		last := i
+		for last < len(tokens) && tokens[last].Start < match.end {
+			last++
+		}
+
+		if last == i {
+			continue
+		}
+
+		first, end := tokens[i], tokens[last-1]
+		result = append(result, token{
+			Token: tokenizer.Token{
+				Type:       TypeAnchor,
+				Text:       text[first.Start:end.End],
+				Normalized: text[first.Start:end.End],
+				Start:      first.Start,
+				End:        end.End,
+				CharStart:  first.CharStart,
+				CharEnd:    end.CharEnd,
+			},
+			anchorType: match.name,
+			date:       match.date,
+		})
+		i = last
+	}
+
+	return append(result, tokens[i:]...)
+}
+
+// createAnchorProximity Функция создает окрестность именованного выражения или даты без соседей.
+// Для даты якорем служит дата в формате ISO 8601, в режиме DateModeTag год дополнительно сохраняется в num
+func createAnchorProximity(sourceDocId string, sourceField string, currentToken token) structs.Proximity {
+	if currentToken.date == "" {
+		return structs.CreateAnchorProximityObject(config.SourceIndex, sourceDocId, sourceField, currentToken.Text, currentToken.anchorType, currentToken.Text)
+	}
+
+	currentProximity := structs.CreateAnchorProximityObject(config.SourceIndex, sourceDocId, sourceField, currentToken.date, currentToken.anchorType, currentToken.Text)
+	currentProximity.Date = currentToken.date
+	if config.DateMode == DateModeTag {
+		year := float64(dateYear(currentToken.date))
+		currentProximity.Num = &year
+	}
+
+	return currentProximity
+}
@@ -0,0 +1,68 @@
+package calculator
+
+import "testing"
+
+func TestParseAnchorPattern(t *testing.T) {
+	tests := []struct {
+		line string
+		name string
+		ok   bool
+	}{
+		{`chemical \b(?:[A-Z][a-z]?\d*){2,}\b`, "chemical", true},
+		{`  standard   ISO\s?\d+  `, "standard", true},
+		{"chemical", "", false},
+		{"broken [a-", "", false},
+	}
+
+	for _, test := range tests {
+		pattern, err := ParseAnchorPattern(test.line)
+		if (err == nil) != test.ok || pattern.Name != test.name {
+			t.Errorf("ParseAnchorPattern(%q) = %q, %v, ожидалось %q, ошибка: %v", test.line, pattern.Name, err, test.name, !test.ok)
+		}
+	}
+}
+
+func TestMergeAnchors(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+
+	var patterns []AnchorPattern
+	for _, line := range []string{`standard ISO\s?\d+`, `chemical \bSiO2\b`, `code ISO\s?\d+-\d+`} {
+		pattern, err := ParseAnchorPattern(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	config = Config{AnchorPatterns: patterns}
+
+	tests := []struct {
+		text    string
+		anchors []string
+	}{
+		{"coating of SiO2 at 5 mm", []string{"chemical:SiO2"}},
+		{"according to ISO 9001 the limit is 5", []string{"standard:ISO 9001"}},
+		{"see ISO 10303-21 and SiO2", []string{"code:ISO 10303-21", "chemical:SiO2"}},
+		{"no anchors at 20 °C", nil},
+	}
+
+	for _, test := range tests {
+		var anchors []string
+		for _, current := range mergeAnchors(test.text, tokenizeText("en", test.text), "en") {
+			if current.Type == TypeAnchor {
+				anchors = append(anchors, current.anchorType+":"+current.Text)
+			}
+		}
+
+		if len(anchors) != len(test.anchors) {
+			t.Errorf("mergeAnchors(%q) = %q, ожидалось %q", test.text, anchors, test.anchors)
+			continue
+		}
+
+		for i := range anchors {
+			if anchors[i] != test.anchors[i] {
+				t.Errorf("mergeAnchors(%q) = %q, ожидалось %q", test.text, anchors, test.anchors)
+				break
+			}
+		}
+	}
+}
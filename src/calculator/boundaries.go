@@ -0,0 +1,129 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/tokenizer"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	BoundaryNone      = "none"
+	BoundarySentence  = "sentence"
+	BoundaryParagraph = "paragraph"
+	BoundaryClaim     = "claim"
+)
+
+// BoundaryModes Допустимые режимы границ окрестности
+var BoundaryModes = []string{BoundaryNone, BoundarySentence, BoundaryParagraph, BoundaryClaim}
+
+var (
+	paragraphRe = regexp.MustCompile(`[\n\r\x{2029}]`)
+	sentenceRe  = regexp.MustCompile(`[.!?;。！？；]`)
+	// abbreviations Сокращения, после которых точка не завершает предложение: "Fig. 3", "рис. 2"
+	abbreviations = map[string]bool{
+		"fig": true, "figs": true, "no": true, "nos": true, "eq": true, "ref": true, "vol": true,
+		"pp": true, "p": true, "approx": true, "ca": true, "e": true, "g": true, "i": true, "vs": true, "etc": true,
+		"рис": true, "см": true, "стр": true, "т": true, "д": true, "ок": true, "прим": true, "п": true, "г": true, "табл": true,
+	}
+)
+
+// IsBoundaryMode Функция проверяет, что режим границ окрестности существует
+func IsBoundaryMode(mode string) bool {
+	for _, boundaryMode := range BoundaryModes {
+		if boundaryMode == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBoundary Функция возвращает режим границ окрестности для поля документа
+func getBoundary(sourceField string) string {
+	if boundary, ok := config.FieldBoundaries[sourceField]; ok {
+		return boundary
+	}
+
+	if config.Boundary == "" {
+		return BoundaryNone
+	}
+
+	return config.Boundary
+}
+
+// segmentTokens Функция разбивает токены на сегменты (предложения, абзацы или пункты формулы) и возвращает номер сегмента каждого токена.
+// Окрестность числа не выходит за пределы его сегмента
+func segmentTokens(text string, tokens []token, mode string) []int {
+	segments := make([]int, len(tokens))
+	if mode == BoundaryNone {
+		return segments
+	}
+
+	segment := 0
+	for i := 1; i < len(tokens); i++ {
+		if isBoundary(text, tokens, i, mode) {
+			segment++
+		}
+		segments[i] = segment
+	}
+
+	return segments
+}
+
+// isBoundary Функция проверяет, начинается ли с i-го токена новый сегмент
+func isBoundary(text string, tokens []token, i int, mode string) bool {
+	gap := text[tokens[i-1].End:tokens[i].Start]
+
+	if paragraphRe.MatchString(gap) {
+		return true
+	}
+
+	switch mode {
+	case BoundarySentence:
+		// номер пункта "2." относится к следующему за ним предложению
+		if i > 1 && isClaimStart(text, tokens, i-1) {
+			return false
+		}
+
+		return isSentenceEnd(gap, tokens[i-1], tokens[i])
+	case BoundaryClaim:
+		return isClaimStart(text, tokens, i)
+	}
+
+	return false
+}
+
+// isSentenceEnd Функция проверяет, что промежуток между токенами завершает предложение:
+// в нем есть знак конца предложения, предыдущее слово не сокращение, а следующее слово не начинается со строчной буквы
+func isSentenceEnd(gap string, previous token, next token) bool {
+	if !sentenceRe.MatchString(gap) {
+		return false
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(gap), ".") && previous.Type == tokenizer.TypeWord && abbreviations[strings.ToLower(previous.Text)] {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(next.Text)
+
+	return !unicode.IsLower(first)
+}
+
+// isClaimStart Функция проверяет, что i-й токен - номер пункта формулы изобретения: "... 50 °C. 2. A device"
+func isClaimStart(text string, tokens []token, i int) bool {
+	current := tokens[i]
+	if current.Type != tokenizer.TypeNumber || strings.ContainsAny(current.Text, ".,") {
+		return false
+	}
+
+	after := text[current.End:]
+	if !strings.HasPrefix(after, ".") && !strings.HasPrefix(after, ")") {
+		return false
+	}
+
+	before := strings.TrimSpace(text[tokens[i-1].End:current.Start])
+
+	return strings.HasSuffix(before, ".") || strings.HasSuffix(before, ";")
+}
@@ -0,0 +1,61 @@
+package calculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSegmentTokens(t *testing.T) {
+	tests := []struct {
+		text     string
+		mode     string
+		expected []int
+	}{
+		{"Heat to 50. Cool to 20", BoundaryNone, []int{0, 0, 0, 0, 0, 0}},
+		{"Heat to 50. Cool to 20", BoundarySentence, []int{0, 0, 0, 1, 1, 1}},
+		{"Heat to 50. cool to 20", BoundarySentence, []int{0, 0, 0, 0, 0, 0}},
+		{"see Fig. 3 for 50 units", BoundarySentence, []int{0, 0, 0, 0, 0, 0}},
+		{"Heat to 50. Cool to 20", BoundaryParagraph, []int{0, 0, 0, 0, 0, 0}},
+		{"Heat to 50\nCool to 20", BoundaryParagraph, []int{0, 0, 0, 1, 1, 1}},
+		{"Heat to 50\nCool to 20", BoundarySentence, []int{0, 0, 0, 1, 1, 1}},
+		{"1. A steel at 50 °C. 2. A device", BoundaryClaim, []int{0, 0, 0, 0, 0, 0, 1, 1, 1}},
+		{"A steel at 50 °C. 2. A device", BoundarySentence, []int{0, 0, 0, 0, 0, 1, 1, 1}},
+	}
+
+	for _, test := range tests {
+		tokens := tokenize("en", test.text)
+		if actual := segmentTokens(test.text, tokens, test.mode); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("segmentTokens(%q, %s) = %v, ожидалось %v", test.text, test.mode, actual, test.expected)
+		}
+	}
+}
+
+func TestGetBoundary(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+
+	config = Config{FieldBoundaries: map[string]string{"claims": BoundaryClaim}}
+	if boundary := getBoundary("text"); boundary != BoundaryNone {
+		t.Errorf("getBoundary(text) без режима = %q, ожидалось %q", boundary, BoundaryNone)
+	}
+
+	config.Boundary = BoundarySentence
+	if boundary := getBoundary("text"); boundary != BoundarySentence {
+		t.Errorf("getBoundary(text) = %q, ожидалось %q", boundary, BoundarySentence)
+	}
+
+	if boundary := getBoundary("claims"); boundary != BoundaryClaim {
+		t.Errorf("getBoundary(claims) = %q, ожидалось %q", boundary, BoundaryClaim)
+	}
+}
+
+func TestCalculateProximitySentenceBoundary(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Windows: []Window{{5, 5}}, Boundary: BoundarySentence}
+
+	result := calculate(t, "text", "en", "Heat the steel to 50. Cool it quickly")
+
+	p := result[Window{5, 5}.IndexName("en")][0]
+	if before, after := neighbourTexts(p.Before), neighbourTexts(p.After); !reflect.DeepEqual(before, []string{"to", "steel", "the", "Heat"}) || len(after) != 0 {
+		t.Errorf("соседи %v %v: окрестность не должна выходить за пределы предложения", before, after)
+	}
+}
@@ -7,11 +7,28 @@ import (
 
 type Config struct {
 	Elastic              elastic.Config
-	ProximityAmbit       int
+	Windows              []Window
+	FieldWindows         map[string]Window
+	LanguageWindows      map[string]Window
+	WindowStrategy       string
+	AnchorTerms          []string
+	AnchorPatterns       []AnchorPattern
+	DateMode             string
+	NoiseRules           []NoiseRule
+	Layout               string
+	IndexShards          int
+	IndexReplicas        int
+	LanguageAnalyzers    map[string]string
+	Rebuild              bool
+	RebuildDeleteOld     bool
 	KeepAlive            int
 	SourceIndex          string
 	ProximityIndexPrefix string
 	PageSize             int
 	UploadChunkSize      int
+	Tokenizer            string
+	LanguageTokenizers   map[string]string
+	Boundary             string
+	FieldBoundaries      map[string]string
 	Start                time.Time
 }
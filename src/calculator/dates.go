@@ -0,0 +1,262 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/numbers"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	AnchorTypeDate = "date"
+
+	// DateModeNone Даты не распознаются и разбираются как обычные числа
+	DateModeNone = "none"
+	// DateModeTag Дата становится якорем, а год сохраняется в num и участвует в поиске чисел
+	DateModeTag = "tag"
+	// DateModeSuppress Дата становится якорем без num и не участвует в поиске чисел
+	DateModeSuppress = "suppress"
+)
+
+// DateModes Допустимые режимы распознавания дат
+var DateModes = []string{DateModeNone, DateModeTag, DateModeSuppress}
+
+// months Названия месяцев (полные, в родительном падеже и сокращенные) и их номера
+var months = map[string]int{
+	"january": 1, "jan": 1, "february": 2, "feb": 2, "march": 3, "mar": 3, "april": 4, "apr": 4,
+	"may": 5, "june": 6, "jun": 6, "july": 7, "jul": 7, "august": 8, "aug": 8,
+	"september": 9, "sept": 9, "sep": 9, "october": 10, "oct": 10, "november": 11, "nov": 11, "december": 12, "dec": 12,
+
+	"январь": 1, "января": 1, "янв": 1, "февраль": 2, "февраля": 2, "фев": 2, "март": 3, "марта": 3,
+	"апрель": 4, "апреля": 4, "апр": 4, "май": 5, "мая": 5, "июнь": 6, "июня": 6, "июль": 7, "июля": 7,
+	"август": 8, "августа": 8, "авг": 8, "сентябрь": 9, "сентября": 9, "сен": 9, "сент": 9,
+	"октябрь": 10, "октября": 10, "окт": 10, "ноябрь": 11, "ноября": 11, "ноя": 11, "нояб": 11,
+	"декабрь": 12, "декабря": 12, "дек": 12,
+}
+
+// yearContext Слова, рядом с которыми четырехзначное число считается годом: "since 2019", "в 2019 году", "2019 г.".
+// После предлогов yearPrepositions ("in 2019", "в 2019") число считается годом, только если оно похоже на год
+// (см. isPlausibleYear): "by 1500 units", "в 1000 раз" и "до 2000 об/мин" остаются количествами
+var (
+	yearBefore       = map[string]bool{"year": true, "since": true, "until": true, "till": true, "год": true, "году": true, "года": true}
+	yearAfter        = map[string]bool{"г": true, "год": true, "года": true, "году": true, "гг": true}
+	yearPrepositions = map[string]bool{"in": true, "by": true, "в": true, "с": true, "до": true, "по": true}
+)
+
+const (
+	minPlausibleYear = 1800
+	maxPlausibleYear = 2100
+)
+
+// datePattern Шаблон даты и порядок групп: d - день, m - месяц (числом или названием), y - год.
+// Для шаблонов с dayFirstOutsideEn порядок "месяц первым" действует только в английском: 03/12/2020
+type datePattern struct {
+	re                *regexp.Regexp
+	order             string
+	dayFirstOutsideEn bool
+}
+
+var datePatterns = buildDatePatterns()
+
+func buildDatePatterns() []datePattern {
+	names := make([]string, 0, len(months))
+	for name := range months {
+		names = append(names, name)
+	}
+
+	// длинные названия раньше сокращений: "march" раньше "mar"
+	sort.Slice(names, func(a, b int) bool {
+		if len(names[a]) != len(names[b]) {
+			return len(names[a]) > len(names[b])
+		}
+
+		return names[a] < names[b]
+	})
+
+	month := `(` + strings.Join(names, "|") + `)\.?`
+	yearSuffix := `(?:\s*(?:г\.|года|год))?`
+
+	return []datePattern{
+		{regexp.MustCompile(`(?i)([0-9]{1,2})\s+` + month + `,?\s+([0-9]{4})` + yearSuffix), "dmy", false},
+		{regexp.MustCompile(`(?i)` + month + `\s+([0-9]{1,2}),?\s+([0-9]{4})`), "mdy", false},
+		{regexp.MustCompile(`(?i)` + month + `\s+([0-9]{4})` + yearSuffix), "my", false},
+		{regexp.MustCompile(`([0-9]{4})-([0-9]{1,2})-([0-9]{1,2})`), "ymd", false},
+		{regexp.MustCompile(`([0-9]{1,2})\.([0-9]{1,2})\.([0-9]{4})` + yearSuffix), "dmy", false},
+		{regexp.MustCompile(`([0-9]{1,2})/([0-9]{1,2})/([0-9]{4})`), "mdy", true},
+	}
+}
+
+// IsDateMode Функция проверяет, что режим распознавания дат существует
+func IsDateMode(mode string) bool {
+	for _, dateMode := range DateModes {
+		if dateMode == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findDates Функция находит в тексте даты: 12.03.2020, 2020-03-12, 03/12/2020, 12 March 2021, March 12, 2021, March 2021, 12 марта 2020 г.
+func findDates(text string, language string) []anchorMatch {
+	var matches []anchorMatch
+
+	for _, pattern := range datePatterns {
+		order := pattern.order
+		if pattern.dayFirstOutsideEn && language != "en" {
+			order = "dmy"
+		}
+
+		for _, location := range pattern.re.FindAllStringSubmatchIndex(text, -1) {
+			if !isWordBoundary(text, location[0], location[1]) {
+				continue
+			}
+
+			groups := make([]string, 0, 3)
+			for g := 1; g < len(location)/2; g++ {
+				groups = append(groups, text[location[2*g]:location[2*g+1]])
+			}
+
+			if date, ok := parseDate(groups, order); ok {
+				matches = append(matches, anchorMatch{name: AnchorTypeDate, start: location[0], end: location[1], date: date})
+			}
+		}
+	}
+
+	return matches
+}
+
+// isWordBoundary Функция проверяет, что совпадение не начинается и не заканчивается внутри слова или числа
+// (\b в регулярных выражениях Go не учитывает кириллицу)
+func isWordBoundary(text string, start int, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (unicode.IsLetter(before) || unicode.IsDigit(before)) {
+		return false
+	}
+
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && (unicode.IsLetter(after) || unicode.IsDigit(after)) {
+		return false
+	}
+
+	return true
+}
+
+// parseDate Функция собирает дату в формате ISO 8601 (2020-03-12 или 2021-03) из групп совпадения в порядке order
+func parseDate(groups []string, order string) (string, bool) {
+	var day, month, year int
+
+	for i, part := range order {
+		value := groups[i]
+
+		switch part {
+		case 'd':
+			day, _ = strconv.Atoi(value)
+		case 'm':
+			if number, err := strconv.Atoi(value); err == nil {
+				month = number
+			} else {
+				month = months[strings.ToLower(value)]
+			}
+		case 'y':
+			year, _ = strconv.Atoi(value)
+		}
+	}
+
+	// 12/25/2020 при порядке "день первым" - перепутанные день и месяц
+	if month > 12 && day >= 1 && day <= 12 {
+		day, month = month, day
+	}
+
+	if month < 1 || month > 12 || year < 1000 {
+		return "", false
+	}
+
+	if !strings.ContainsRune(order, 'd') {
+		return fmt.Sprintf("%04d-%02d", year, month), true
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || date.Day() != day {
+		return "", false
+	}
+
+	return date.Format("2006-01-02"), true
+}
+
+// markYears Функция превращает четырехзначные числа рядом со словами "in", "since", "в", "году", "г." и т.п. в даты.
+// Контекст года проверяется до единицы измерения: в "2019г." буква "г" - сокращение слова "год", а не граммы
+func markYears(text string, tokens []token, language string) []token {
+	for i := range tokens {
+		current := tokens[i]
+		if !current.isNumber || current.isRange || current.spelledOut || len(current.Text) != 4 ||
+			current.num < 1000 || current.num > 2100 || current.num != float64(int(current.num)) {
+			continue
+		}
+
+		if !isYearContext(text, tokens, i, language) {
+			continue
+		}
+
+		tokens[i].Type = TypeAnchor
+		tokens[i].isNumber = false
+		tokens[i].hasUnit = false
+		tokens[i].anchorType = AnchorTypeDate
+		tokens[i].date = strconv.Itoa(int(current.num))
+	}
+
+	return tokens
+}
+
+// isYearContext Функция проверяет, что число окружено словами, указывающими на год.
+// Одиночное "г" считается годом только с точкой ("2019 г."), иначе это граммы ("1500 г муки").
+// Число с другой единицей измерения годом не считается
+func isYearContext(text string, tokens []token, i int, language string) bool {
+	if tokens[i].hasUnit && tokens[i].unit.Symbol != "g" {
+		return false
+	}
+
+	if i > 0 && yearBefore[tokens[i-1].Normalized] {
+		return true
+	}
+
+	if i > 0 && yearPrepositions[tokens[i-1].Normalized] && isPlausibleYear(tokens, i, language) {
+		return true
+	}
+
+	if i+1 >= len(tokens) || !yearAfter[tokens[i+1].Normalized] {
+		return false
+	}
+
+	next := tokens[i+1]
+	if next.Normalized == "г" {
+		return strings.HasPrefix(text[next.End:], ".")
+	}
+
+	return true
+}
+
+// isPlausibleYear Функция проверяет, что число после предлога похоже на год: лежит в пределах minPlausibleYear..maxPlausibleYear,
+// не имеет единицы измерения и за ним не следует число или числительное ("в 2000 тысяч")
+func isPlausibleYear(tokens []token, i int, language string) bool {
+	current := tokens[i]
+	if current.hasUnit || current.num < minPlausibleYear || current.num > maxPlausibleYear {
+		return false
+	}
+
+	if i+1 < len(tokens) && (tokens[i+1].isNumber || numbers.IsNumberWord(tokens[i+1].Text, language)) {
+		return false
+	}
+
+	return true
+}
+
+// dateYear Функция возвращает год даты в формате ISO 8601
+func dateYear(date string) int {
+	year, _ := strconv.Atoi(strings.SplitN(date, "-", 2)[0])
+
+	return year
+}
@@ -0,0 +1,84 @@
+package calculator
+
+import "testing"
+
+func TestMergeDates(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{DateMode: DateModeTag}
+
+	tests := []struct {
+		text     string
+		language string
+		dates    []string
+	}{
+		{"поставка 12.03.2020 на склад", "ru", []string{"2020-03-12"}},
+		{"released 2020-03-12", "en", []string{"2020-03-12"}},
+		{"due 03/12/2020", "en", []string{"2020-03-12"}},
+		{"срок 03/12/2020", "ru", []string{"2020-12-03"}},
+		{"due 25/12/2020", "en", []string{"2020-12-25"}},
+		{"on 12 March 2021 and March 12, 2021", "en", []string{"2021-03-12", "2021-03-12"}},
+		{"in March 2021", "en", []string{"2021-03"}},
+		{"12 марта 2020 г. было", "ru", []string{"2020-03-12"}},
+		{"31.02.2020", "ru", nil},
+		{"v112.03.2020", "en", nil},
+		{"5 mm", "en", nil},
+	}
+
+	for _, test := range tests {
+		var dates []string
+		for _, current := range mergeAnchors(test.text, tokenize(test.language, test.text), test.language) {
+			if current.anchorType == AnchorTypeDate {
+				dates = append(dates, current.date)
+			}
+		}
+
+		if len(dates) != len(test.dates) {
+			t.Errorf("mergeAnchors(%q, %q) = %q, ожидалось %q", test.text, test.language, dates, test.dates)
+			continue
+		}
+
+		for i := range dates {
+			if dates[i] != test.dates[i] {
+				t.Errorf("mergeAnchors(%q, %q) = %q, ожидалось %q", test.text, test.language, dates, test.dates)
+				break
+			}
+		}
+	}
+}
+
+func TestMarkYears(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		year     string
+	}{
+		{"founded in 2019 with 5 staff", "en", "2019"},
+		{"основана в 2019 году", "ru", "2019"},
+		{"выпуск 2019 г. был малым", "ru", "2019"},
+		{"добавить 1500 г муки", "ru", ""},
+		{"масса 2019 кг", "ru", ""},
+		{"in 3000 cases", "en", ""},
+		{"total 2019 items", "en", ""},
+		{"since 1750 the method", "en", "1750"},
+		{"reduced by 1500 units in 1200 samples", "en", ""},
+		{"увеличилась в 1000 раз", "ru", ""},
+		{"до 2000 об/мин", "ru", ""},
+		{"в 2000 тысяч", "ru", ""},
+		{"by 2020 the output", "en", "2020"},
+	}
+
+	for _, test := range tests {
+		tokens := markYears(test.text, attachUnits(test.text, tokenize(test.language, test.text)), test.language)
+
+		year := ""
+		for _, current := range tokens {
+			if current.anchorType == AnchorTypeDate {
+				year = current.date
+			}
+		}
+
+		if year != test.year {
+			t.Errorf("markYears(%q) = %q, ожидалось %q", test.text, year, test.year)
+		}
+	}
+}
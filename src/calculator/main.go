@@ -7,14 +7,12 @@ import (
 	"elastic-proximity-calculation/src/helpers"
 	"elastic-proximity-calculation/src/logger"
 	"elastic-proximity-calculation/src/structs"
-	"encoding/json"
 	"fmt"
 	"github.com/dustin/go-humanize"
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/elastic/go-elasticsearch/v7/esutil"
 	"github.com/tidwall/gjson"
 	"math"
-	"regexp"
 	"runtime"
 	"strconv"
 	"sync"
@@ -30,9 +28,11 @@ var (
 	totalErrorUploads     int64          = 0
 	totalDocsCount        int64          = 0
 	wg                    sync.WaitGroup = sync.WaitGroup{}
-	re                    *regexp.Regexp = regexp.MustCompile(`([0-9]*[.,]*[0-9]+)|\p{L}+`)
+	tokenizers            sync.Map       = sync.Map{}
 	proximities                          = structs.NewContainer()
 	client                *elasticsearch.Client
+	// encoder Кодировщик окрестностей для формата записи соседей config.Layout
+	encoder structs.Encoder
 
 	config Config
 )
@@ -40,7 +40,17 @@ var (
 func Do(initConfig Config) {
 	config = initConfig
 
+	var ok bool
+	if encoder, ok = structs.GetEncoder(config.Layout); !ok {
+		logger.Error("Неизвестный формат записи соседей: %s", config.Layout)
+	}
+
 	client = elastic.GetElasticsearchClient(config.Elastic)
+	initTerms()
+	if config.Rebuild {
+		checkRebuild()
+	}
+	installTemplates()
 
 	keepAliveNew := time.Duration(config.KeepAlive) * time.Minute
 
@@ -49,6 +59,7 @@ func Do(initConfig Config) {
 		client.Search.WithSort("common.publication_date"),
 		client.Search.WithSize(config.PageSize),
 		client.Search.WithScroll(keepAliveNew),
+		client.Search.WithTrackTotalHits(true),
 	)
 
 	if err != nil {
@@ -103,6 +114,10 @@ func Do(initConfig Config) {
 
 	elastic.CloseBulkIndexers()
 
+	if config.Rebuild {
+		swapGenerations()
+	}
+
 	dur := time.Since(config.Start)
 	logger.Info("Выполнено. Общее время выполнения: %s", dur.String())
 	logger.Info("Общее количество успешных загрузок: %s", strconv.FormatInt(totalSuccessUploads, 10))
@@ -112,14 +127,16 @@ func Do(initConfig Config) {
 func upload(startTime time.Time) {
 	logger.Info("Начало загрузки [%s]", strconv.Itoa(uploadsCount))
 
-	for language, currentProximities := range proximities.GetAll() {
-		bi := elastic.GetBulkIndexer(client, config.ProximityIndexPrefix, language, config.ProximityAmbit)
+	for index, currentProximities := range proximities.GetAll() {
+		target := targetIndex(index)
+		checkTargetIndex(target)
+		bi := elastic.GetBulkIndexer(client, target)
 
 		var countSuccessful uint64
 		start := time.Now().UTC()
 
 		for _, proximity := range currentProximities {
-			data, err := json.Marshal(proximity)
+			data, err := encoder.Encode(proximity)
 			if err != nil {
 				logger.Error("Ошибка кодирования JSON")
 			}
@@ -162,8 +179,8 @@ func upload(startTime time.Time) {
 		if biStats.NumFailed > 0 {
 			logger.Warning(
 				fmt.Sprintf(
-					"Для языка [%s] индексировано [%s] окрестностей с [%s] ошибками за %s (%s документов в секунду)",
-					language,
+					"Для индекса [%s] индексировано [%s] окрестностей с [%s] ошибками за %s (%s документов в секунду)",
+					index,
 					humanize.Comma(int64(biStats.NumFlushed)),
 					humanize.Comma(int64(biStats.NumFailed)),
 					dur.Truncate(time.Millisecond).String(),
@@ -173,8 +190,8 @@ func upload(startTime time.Time) {
 		} else {
 			logger.Info(
 				fmt.Sprintf(
-					"Для языка [%s] индексировано [%s] окрестностей за %s (%s документов в секунду)",
-					language,
+					"Для индекса [%s] индексировано [%s] окрестностей за %s (%s документов в секунду)",
+					index,
 					humanize.Comma(int64(biStats.NumFlushed)),
 					dur.Truncate(time.Millisecond).String(),
 					humanize.Comma(int64(1000.0/float64(dur/time.Millisecond)*float64(biStats.NumFlushed))),
@@ -182,7 +199,7 @@ func upload(startTime time.Time) {
 			)
 		}
 
-		proximities.DeleteByLanguage(language)
+		proximities.DeleteByIndex(index)
 	}
 
 	logger.Info("Обработано документов за цикл: %s", strconv.Itoa(uploadsDocsCount))
@@ -230,72 +247,197 @@ func processSingleHit(i int, hit gjson.Result) {
 				}
 			}
 		}
-		uploadsDocsTotalCount++
+		atomic.AddInt64(&uploadsDocsTotalCount, 1)
 		uploadsDocsCount++
 	}
 
 }
 
 func calculateProximity(sourceDocId string, sourceField string, language string, textField string) {
-	tokens := re.FindAll([]byte(textField), -1)
+	tokens := tokenize(language, textField)
+	tokens = mergeAnchors(textField, tokens, language)
+	tokens = mergeSpelledNumbers(textField, tokens, language)
+	tokens = mergeRanges(textField, tokens)
+	tokens = attachUnits(textField, tokens)
+	if config.DateMode != "" && config.DateMode != DateModeNone {
+		tokens = markYears(textField, tokens, language)
+	}
 	tokensLength := len(tokens)
+	segments := segmentTokens(textField, tokens, getBoundary(sourceField))
+	windows := getWindows(sourceField, language)
+	noise := detectNoise(textField, tokens)
 
 	for i := 0; i < tokensLength; i++ {
 		currentToken := tokens[i]
-		if helpers.IsNumber(string(currentToken)) {
-			currentNumber := helpers.StringToFloat64(string(currentToken))
-			currentProximity := structs.CreateProximityObject(config.SourceIndex, sourceDocId, sourceField, currentNumber)
+		if currentToken.isNumber && (noise[i] == nil || noise[i].Action != NoiseDrop) {
+			currentProximity := createProximity(sourceDocId, sourceField, currentToken)
+			if noise[i] != nil {
+				currentProximity.Noise = noise[i].Name
+			}
 
-			var (
-				left  int
-				right int
-			)
+			addProximities(currentProximity, tokens, segments, noise, i, i, windows, language)
+		}
 
-			if i-config.ProximityAmbit > 0 {
-				left = i - config.ProximityAmbit
-			} else {
-				left = 0
-			}
+		if currentToken.anchorType != "" {
+			addProximities(createAnchorProximity(sourceDocId, sourceField, currentToken), tokens, segments, noise, i, i, windows, language)
+			continue
+		}
 
-			if i+config.ProximityAmbit < tokensLength {
-				right = i + config.ProximityAmbit
-			} else {
-				right = tokensLength - 1
-			}
+		if term, length := matchTerm(tokens, i); length > 0 {
+			last := i + length - 1
+			termProximity := createTermProximity(sourceDocId, sourceField, term, textField[tokens[i].Start:tokens[last].End])
+			addProximities(termProximity, tokens, segments, noise, i, last, windows, language)
+		}
+	}
+}
 
-			for j := left; j <= right; j++ {
-				if j < i {
-					var needleIndex string = strconv.Itoa(getNeedleIndex(i, j))
-					if helpers.IsNumber(string(tokens[j])) {
-						tmpNumber := helpers.StringToFloat64(string(tokens[j]))
-						currentProximity["nb_"+needleIndex] = tmpNumber
-						currentProximity["tb_"+needleIndex] = tokens[j]
-					} else {
-						currentProximity["tb_"+needleIndex] = tokens[j]
-					}
-				} else if j > i {
-					var needleIndex string = strconv.Itoa(getNeedleIndex(i, j))
-					if helpers.IsNumber(string(tokens[j])) {
-						tmpNumber := helpers.StringToFloat64(string(tokens[j]))
-						currentProximity["na_"+needleIndex] = tmpNumber
-						currentProximity["ta_"+needleIndex] = tokens[j]
-					} else {
-						currentProximity["ta_"+needleIndex] = tokens[j]
-					}
-				}
-			}
+// addProximities Функция добавляет окрестности токенов first..last для каждой размерности окрестности.
+// В окрестность якоря (см. anchor_type) дополнительно добавляется список чисел вокруг него без шумовых чисел (см. detectNoise)
+func addProximities(baseProximity structs.Proximity, tokens []token, segments []int, noise []*NoiseRule, first int, last int, windows []Window, language string) {
+	for _, window := range windows {
+		currentProximity := baseProximity
+		currentProximity.WindowLeft = window.Left
+		currentProximity.WindowRight = window.Right
+		if config.WindowStrategy != structs.StrategyTokens {
+			currentProximity.WindowStrategy = config.WindowStrategy
+		}
 
-			proximities.Add(language, &currentProximity)
+		neighbours := setNeighbours(&currentProximity, tokens, segments, first, last, window)
+		if currentProximity.IsAnchor() {
+			currentProximity.Numbers = getNumbers(neighbours, noise)
 		}
+
+		proximities.Add(window.IndexName(language), &currentProximity)
 	}
 }
 
-func getNeedleIndex(center int, current int) int {
-	if current < center {
-		return center - current
-	} else if current > center {
-		return current - center
+// createProximity Функция создает окрестность числа без соседей
+func createProximity(sourceDocId string, sourceField string, currentToken token) structs.Proximity {
+	var currentProximity structs.Proximity
+	if currentToken.isRange {
+		currentProximity = structs.CreateRangeProximityObject(config.SourceIndex, sourceDocId, sourceField, currentToken.numMin, currentToken.numMax, currentToken.Text, currentToken.expressionType)
+		if currentToken.hasNominal {
+			num := currentToken.num
+			currentProximity.Num = &num
+		}
 	} else {
-		return 0
+		currentProximity = structs.CreateProximityObject(config.SourceIndex, sourceDocId, sourceField, currentToken.num)
+		setLiteral(&currentProximity, currentToken)
+	}
+	setUnit(&currentProximity.Value, currentToken)
+
+	currentProximity.SpelledOut = currentToken.spelledOut
+
+	return currentProximity
+}
+
+// setLiteral Функция сохраняет в окрестности исходную запись числа, количество значащих цифр
+// и подразумеваемый записью интервал (для 5.00 - [4.995, 5.005])
+func setLiteral(currentProximity *structs.Proximity, currentToken token) {
+	currentProximity.Literal = currentToken.Text
+	if !currentToken.hasLiteral {
+		return
+	}
+
+	currentProximity.SignificantDigits = currentToken.literal.SignificantDigits
+	if impliedMin, impliedMax, ok := currentToken.literal.ImpliedInterval(currentToken.num); ok {
+		currentProximity.NumImpliedMin = &impliedMin
+		currentProximity.NumImpliedMax = &impliedMax
 	}
 }
+
+// neighbour Сосед центра окрестности. Расстояние отрицательное для соседей слева
+type neighbour struct {
+	token token
+	// index Номер токена соседа в тексте
+	index    int
+	position int
+	distance int
+}
+
+// setNeighbours Функция добавляет в окрестность токенов first..last соседей в пределах размерности окрестности и границ сегмента
+// и возвращает их вместе с пропущенными числами (см. getSideNeighbours) для списка чисел вокруг якоря
+func setNeighbours(currentProximity *structs.Proximity, tokens []token, segments []int, first int, last int, window Window) []neighbour {
+	before, skippedBefore := getSideNeighbours(tokens, segments, first, -1, window.Left)
+	after, skippedAfter := getSideNeighbours(tokens, segments, last, 1, window.Right)
+
+	currentProximity.Before = toNeighbours(before)
+	currentProximity.After = toNeighbours(after)
+
+	neighbours := append(before, after...)
+	neighbours = append(neighbours, skippedBefore...)
+
+	return append(neighbours, skippedAfter...)
+}
+
+// getSideNeighbours Функция возвращает соседей с одной стороны от i-го токена (step = -1 - слева, 1 - справа).
+// Номер соседа - его расстояние от числа в токенах или словах (см. structs.WindowStrategies), при подсчете в символах - порядковый.
+// При подсчете в словах числа не становятся соседями и возвращаются отдельно: расстояние до числа равно номеру следующего за ним слова
+func getSideNeighbours(tokens []token, segments []int, i int, step int, size int) ([]neighbour, []neighbour) {
+	var neighbours, skipped []neighbour
+	position := 0
+
+	for j := i + step; j >= 0 && j < len(tokens) && segments[j] == segments[i]; j += step {
+		if config.WindowStrategy == structs.StrategyWords && tokens[j].isNumber {
+			if position+1 > size {
+				break
+			}
+
+			skipped = append(skipped, neighbour{token: tokens[j], index: j, position: position + 1, distance: (position + 1) * step})
+			continue
+		}
+
+		position++
+		distance := position
+		if config.WindowStrategy == structs.StrategyChars {
+			distance = charDistance(tokens[i], tokens[j])
+		}
+
+		if distance > size {
+			break
+		}
+
+		neighbours = append(neighbours, neighbour{token: tokens[j], index: j, position: position, distance: distance * step})
+	}
+
+	return neighbours, skipped
+}
+
+// toNeighbours Функция переводит соседей в формат окрестности. Расстояние в символах сохраняется только при подсчете в символах
+func toNeighbours(neighbours []neighbour) []structs.Neighbour {
+	result := make([]structs.Neighbour, 0, len(neighbours))
+
+	for _, n := range neighbours {
+		current := structs.Neighbour{Position: n.position, Text: n.token.Text}
+		if hasSingleNumber(n.token) {
+			num := n.token.num
+			current.Num = &num
+		}
+
+		if config.WindowStrategy == structs.StrategyChars {
+			distance := n.distance
+			if distance < 0 {
+				distance = -distance
+			}
+			current.Distance = &distance
+		}
+
+		result = append(result, current)
+	}
+
+	return result
+}
+
+// charDistance Функция возвращает количество символов между токенами
+func charDistance(center token, current token) int {
+	if current.CharStart < center.CharStart {
+		return center.CharStart - current.CharEnd
+	}
+
+	return current.CharStart - center.CharEnd
+}
+
+// hasSingleNumber Функция проверяет, что токен можно представить одним числом (диапазон без номинала - нельзя)
+func hasSingleNumber(t token) bool {
+	return t.isNumber && (!t.isRange || t.hasNominal)
+}
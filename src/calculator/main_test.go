@@ -0,0 +1,109 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"reflect"
+	"testing"
+)
+
+// calculate Функция рассчитывает окрестности поля документа с текущей конфигурацией и возвращает их по индексам окрестностей
+func calculate(t *testing.T, sourceField string, language string, text string) map[string][]*structs.Proximity {
+	previous := proximities
+	t.Cleanup(func() { proximities = previous })
+
+	proximities = structs.NewContainer()
+	calculateProximity("1", sourceField, language, text)
+
+	return proximities.GetAll()
+}
+
+// neighbourTexts Функция возвращает тексты соседей окрестности в порядке удаления от центра
+func neighbourTexts(neighbours []structs.Neighbour) []string {
+	texts := []string{}
+	for _, n := range neighbours {
+		texts = append(texts, n.Text)
+	}
+
+	return texts
+}
+
+func TestCalculateProximitySeveralWindows(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{
+		Windows:              []Window{{1, 1}, {3, 3}, {0, 2}},
+		WindowStrategy:       structs.StrategyTokens,
+		ProximityIndexPrefix: "p_",
+	}
+
+	result := calculate(t, "text", "en", "the oven temperature reached 150 degrees inside the chamber")
+	if len(result) != len(config.Windows) {
+		t.Fatalf("calculateProximity() записал окрестности в %d индексов, ожидалось %d", len(result), len(config.Windows))
+	}
+
+	tests := []struct {
+		window Window
+		before []string
+		after  []string
+	}{
+		{Window{1, 1}, []string{"reached"}, []string{"degrees"}},
+		{Window{3, 3}, []string{"reached", "temperature", "oven"}, []string{"degrees", "inside", "the"}},
+		{Window{0, 2}, []string{}, []string{"degrees", "inside"}},
+	}
+
+	for _, test := range tests {
+		found := result[test.window.IndexName("en")]
+		if len(found) != 1 {
+			t.Errorf("окрестность %v: найдено %d окрестностей, ожидалась одна", test.window, len(found))
+			continue
+		}
+
+		p := found[0]
+		if p.WindowLeft != test.window.Left || p.WindowRight != test.window.Right {
+			t.Errorf("окрестность %v: размерность %d:%d", test.window, p.WindowLeft, p.WindowRight)
+		}
+
+		if before, after := neighbourTexts(p.Before), neighbourTexts(p.After); !reflect.DeepEqual(before, test.before) || !reflect.DeepEqual(after, test.after) {
+			t.Errorf("окрестность %v: соседи %v %v, ожидалось %v %v", test.window, before, after, test.before, test.after)
+		}
+	}
+}
+
+func TestCalculateProximityWordStrategy(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Windows: []Window{{2, 2}}, WindowStrategy: structs.StrategyWords}
+
+	result := calculate(t, "text", "en", "water heated to 150 then 200 degrees")
+
+	p := result[Window{2, 2}.IndexName("en")][0]
+	if p.WindowStrategy != structs.StrategyWords {
+		t.Errorf("WindowStrategy = %q, ожидалось %q", p.WindowStrategy, structs.StrategyWords)
+	}
+
+	// число 200 не становится соседом и не занимает номер: degrees - второе слово после 150
+	if before, after := neighbourTexts(p.Before), neighbourTexts(p.After); !reflect.DeepEqual(before, []string{"to", "heated"}) || !reflect.DeepEqual(after, []string{"then", "degrees"}) {
+		t.Errorf("соседи %v %v", before, after)
+	}
+
+	if p.After[1].Position != 2 || p.After[1].Distance != nil {
+		t.Errorf("сосед degrees = %+v, ожидался номер 2 без расстояния в символах", p.After[1])
+	}
+}
+
+func TestCalculateProximityCharStrategy(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Windows: []Window{{5, 5}}, WindowStrategy: structs.StrategyChars}
+
+	result := calculate(t, "text", "en", "at 150 was hot ok")
+
+	p := result[Window{5, 5}.IndexName("en")][0]
+	if before, after := neighbourTexts(p.Before), neighbourTexts(p.After); !reflect.DeepEqual(before, []string{"at"}) || !reflect.DeepEqual(after, []string{"was", "hot"}) {
+		t.Fatalf("соседи %v %v", before, after)
+	}
+
+	expected := []int{1, 5}
+	for i, n := range p.After {
+		if n.Position != i+1 || n.Distance == nil || *n.Distance != expected[i] {
+			t.Errorf("сосед %q: номер %d, расстояние %v, ожидалось %d", n.Text, n.Position, n.Distance, expected[i])
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package calculator
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// NoiseDrop Окрестность шумового числа не создается
+	NoiseDrop = "drop"
+	// NoiseTag Окрестность шумового числа создается с полем noise = имя правила
+	NoiseTag = "tag"
+)
+
+// NoiseRule Правило распознавания шумовых чисел: ссылок на рисунки, пункты формулы, позиции элементов, номеров страниц и списков
+type NoiseRule struct {
+	Name   string
+	Action string
+	// Pattern Число считается шумом, если оно целиком попадает в совпадение
+	Pattern *regexp.Regexp
+	// Before, After Число считается шумом, если перед ним (после него) стоит одно из слов
+	Before map[string]bool
+	After  map[string]bool
+	// List Число считается шумом, если это номер пункта списка или формулы: "1. ...", "2) ..."
+	List bool
+}
+
+// builtinNoiseRules Встроенные правила для патентных текстов
+var builtinNoiseRules = map[string]NoiseRule{
+	"figure":  {Before: wordSet("fig", "figs", "figure", "figures", "рис", "рисунок", "рисунка", "рисунке", "рисунках", "рисунками", "фиг")},
+	"claim":   {Before: wordSet("claim", "claims", "пункт", "пункта", "пункту", "пунктам", "пунктах", "пунктов", "п", "пп")},
+	"element": {Before: wordSet("element", "elements", "member", "members", "numeral", "numerals", "позиция", "позиции", "поз", "элемент", "элемента")},
+	"page":    {Before: wordSet("page", "pages", "p", "pp", "стр", "страница", "странице", "страницы")},
+	"list":    {List: true},
+}
+
+var noiseRuleRe = regexp.MustCompile(`^\s*(\S+)\s+(\S+)\s+(\S+)\s+(.+)$`)
+
+// noiseConjunctions Союзы, через которые правило распространяется на следующее число: "claims 1, 2 and 3", "рис. 1 и 2"
+var noiseConjunctions = wordSet("and", "or", "и", "или")
+
+func wordSet(words ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
+
+	return set
+}
+
+// NoiseRuleNames Функция возвращает имена встроенных правил
+func NoiseRuleNames() []string {
+	names := make([]string, 0, len(builtinNoiseRules))
+	for name := range builtinNoiseRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// BuiltinNoiseRule Функция возвращает встроенное правило с указанным действием
+func BuiltinNoiseRule(name string, action string) (NoiseRule, error) {
+	rule, ok := builtinNoiseRules[name]
+	if !ok {
+		return NoiseRule{}, errors.New("неизвестное правило: " + name + " (доступны: " + strings.Join(NoiseRuleNames(), ", ") + ")")
+	}
+
+	if err := checkNoiseAction(action); err != nil {
+		return NoiseRule{}, err
+	}
+
+	rule.Name, rule.Action = name, action
+
+	return rule, nil
+}
+
+// ParseNoiseRule Функция для разбора пользовательского правила вида
+// "имя действие regex выражение", "имя действие before слово1,слово2" или "имя действие after слово1,слово2"
+func ParseNoiseRule(line string) (NoiseRule, error) {
+	parts := noiseRuleRe.FindStringSubmatch(line)
+	if parts == nil {
+		return NoiseRule{}, errors.New("ожидалась строка вида \"имя действие regex|before|after значение\", получено: " + line)
+	}
+	parts = parts[1:]
+
+	if err := checkNoiseAction(parts[1]); err != nil {
+		return NoiseRule{}, err
+	}
+
+	rule := NoiseRule{Name: parts[0], Action: parts[1]}
+	value := strings.TrimSpace(parts[3])
+
+	switch parts[2] {
+	case "regex":
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return NoiseRule{}, errors.New(rule.Name + ": " + err.Error())
+		}
+		rule.Pattern = pattern
+	case "before":
+		rule.Before = wordSet(strings.Split(value, ",")...)
+	case "after":
+		rule.After = wordSet(strings.Split(value, ",")...)
+	default:
+		return NoiseRule{}, errors.New(rule.Name + ": неизвестный вид правила " + parts[2] + " (допустимо: regex, before, after)")
+	}
+
+	return rule, nil
+}
+
+func checkNoiseAction(action string) error {
+	if action != NoiseDrop && action != NoiseTag {
+		return errors.New("неизвестное действие: " + action + " (допустимо: " + NoiseDrop + ", " + NoiseTag + ")")
+	}
+
+	return nil
+}
+
+// detectNoise Функция возвращает для каждого числа первое сработавшее правило (nil - число не шумовое)
+func detectNoise(text string, tokens []token) []*NoiseRule {
+	noise := make([]*NoiseRule, len(tokens))
+	if len(config.NoiseRules) == 0 {
+		return noise
+	}
+
+	spans := make([][][]int, len(config.NoiseRules))
+	for r, rule := range config.NoiseRules {
+		if rule.Pattern != nil {
+			spans[r] = rule.Pattern.FindAllStringIndex(text, -1)
+		}
+	}
+
+	for i := range tokens {
+		if !tokens[i].isNumber {
+			continue
+		}
+
+		for r := range config.NoiseRules {
+			if matchNoiseRule(&config.NoiseRules[r], spans[r], text, tokens, i) {
+				noise[i] = &config.NoiseRules[r]
+				break
+			}
+		}
+
+		if noise[i] == nil {
+			noise[i] = inheritNoise(text, tokens, noise, i)
+		}
+	}
+
+	return noise
+}
+
+func matchNoiseRule(rule *NoiseRule, spans [][]int, text string, tokens []token, i int) bool {
+	current := tokens[i]
+
+	for _, span := range spans {
+		if span[0] <= current.Start && current.End <= span[1] {
+			return true
+		}
+	}
+
+	if rule.Before != nil && i > 0 && rule.Before[tokens[i-1].Normalized] && isReferenceGap(text[tokens[i-1].End:current.Start]) {
+		return true
+	}
+
+	if rule.After != nil && i+1 < len(tokens) && rule.After[tokens[i+1].Normalized] && isReferenceGap(text[current.End:tokens[i+1].Start]) {
+		return true
+	}
+
+	return rule.List && isListNumber(text, tokens, i)
+}
+
+// inheritNoise Функция распространяет правило предыдущего шумового числа через запятую или союз: "claims 1, 2 and 3"
+func inheritNoise(text string, tokens []token, noise []*NoiseRule, i int) *NoiseRule {
+	if i > 0 && noise[i-1] != nil && strings.TrimSpace(text[tokens[i-1].End:tokens[i].Start]) == "," {
+		return noise[i-1]
+	}
+
+	if i > 1 && noise[i-2] != nil && noiseConjunctions[tokens[i-1].Normalized] {
+		return noise[i-2]
+	}
+
+	return nil
+}
+
+// isReferenceGap Функция проверяет промежуток между словом и номером: "claim 1", "Fig. 3", "рис.3"
+func isReferenceGap(gap string) bool {
+	gap = strings.TrimSpace(gap)
+
+	return gap == "" || gap == "."
+}
+
+// isListNumber Функция проверяет, что число - номер пункта списка: стоит в начале строки (или пункта формулы)
+// и после него идет "." или ")"
+func isListNumber(text string, tokens []token, i int) bool {
+	current := tokens[i]
+	if current.isRange || strings.ContainsAny(current.Text, ".,") {
+		return false
+	}
+
+	after := text[current.End:]
+	if !strings.HasPrefix(after, ".") && !strings.HasPrefix(after, ")") {
+		return false
+	}
+
+	lineStart := strings.LastIndexAny(text[:current.Start], "\n\r") + 1
+	if strings.TrimSpace(strings.TrimLeft(text[lineStart:current.Start], "(")) == "" {
+		return true
+	}
+
+	return i > 0 && isClaimStart(text, tokens, i)
+}
@@ -0,0 +1,131 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"testing"
+)
+
+func TestBuiltinNoiseRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		ok     bool
+	}{
+		{"figure", NoiseDrop, true},
+		{"list", NoiseTag, true},
+		{"figure", "skip", false},
+		{"unknown", NoiseDrop, false},
+	}
+
+	for _, test := range tests {
+		rule, err := BuiltinNoiseRule(test.name, test.action)
+		if (err == nil) != test.ok || (test.ok && (rule.Name != test.name || rule.Action != test.action)) {
+			t.Errorf("BuiltinNoiseRule(%q, %q) = %+v, %v", test.name, test.action, rule, err)
+		}
+	}
+}
+
+func TestParseNoiseRule(t *testing.T) {
+	tests := []struct {
+		line  string
+		ok    bool
+		check func(NoiseRule) bool
+	}{
+		{`table tag regex Table\s+\d+`, true, func(rule NoiseRule) bool { return rule.Pattern.MatchString("Table 12") }},
+		{"sheet drop before sheet,лист", true, func(rule NoiseRule) bool { return rule.Before["лист"] && rule.Before["sheet"] }},
+		{"times tag after times,раз", true, func(rule NoiseRule) bool { return rule.After["раз"] }},
+		{"sheet skip before sheet", false, nil},
+		{"sheet drop near sheet", false, nil},
+		{"table tag regex [", false, nil},
+		{"table tag", false, nil},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseNoiseRule(test.line)
+		if (err == nil) != test.ok || (test.ok && !test.check(rule)) {
+			t.Errorf("ParseNoiseRule(%q) = %+v, %v", test.line, rule, err)
+		}
+	}
+}
+
+func TestDetectNoise(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+
+	var rules []NoiseRule
+	for _, name := range []string{"figure", "claim", "list"} {
+		rule, err := BuiltinNoiseRule(name, NoiseTag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+	table, err := ParseNoiseRule(`table drop regex Table\s+\d+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config = Config{NoiseRules: append(rules, table)}
+
+	tests := []struct {
+		text  string
+		noise map[string]string
+	}{
+		{"as shown in Fig. 3 the gap is 5 mm", map[string]string{"3": "figure", "5": ""}},
+		{"according to claims 1, 2 and 3 the value is 7", map[string]string{"1": "claim", "2": "claim", "3": "claim", "7": ""}},
+		{"1. A device heated to 300 °C", map[string]string{"1": "list", "300": ""}},
+		{"see Table 4 for 12 samples", map[string]string{"4": "table", "12": ""}},
+		{"рис.2 показывает 10 мм", map[string]string{"2": "figure", "10": ""}},
+	}
+
+	for _, test := range tests {
+		tokens := tokenize("en", test.text)
+		noise := detectNoise(test.text, tokens)
+		found := 0
+
+		for i, current := range tokens {
+			expected, ok := test.noise[current.Text]
+			if !ok {
+				continue
+			}
+			found++
+
+			name := ""
+			if noise[i] != nil {
+				name = noise[i].Name
+			}
+
+			if name != expected {
+				t.Errorf("detectNoise(%q) для %q = %q, ожидалось %q", test.text, current.Text, name, expected)
+			}
+		}
+
+		if found != len(test.noise) {
+			t.Errorf("detectNoise(%q): найдено %d чисел из %d", test.text, found, len(test.noise))
+		}
+	}
+}
+
+func TestGetNumbersSkipsNoise(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+
+	claim, err := BuiltinNoiseRule("claim", NoiseTag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	figure, err := BuiltinNoiseRule("figure", NoiseDrop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config = Config{NoiseRules: []NoiseRule{claim, figure}, WindowStrategy: structs.StrategyTokens}
+
+	text := "steel according to claim 1 and Fig. 3 is 5 thick"
+	tokens := tokenize("en", text)
+	noise := detectNoise(text, tokens)
+
+	var proximity structs.Proximity
+	neighbours := setNeighbours(&proximity, tokens, make([]int, len(tokens)), 0, 0, Window{Left: 20, Right: 20})
+
+	numbers := getNumbers(neighbours, noise)
+	if len(numbers) != 1 || numbers[0].Num == nil || *numbers[0].Num != 5 {
+		t.Errorf("getNumbers(%q) = %+v, ожидалось только число 5", text, numbers)
+	}
+}
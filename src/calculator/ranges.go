@@ -0,0 +1,134 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/helpers"
+	"elastic-proximity-calculation/src/numbers"
+	"elastic-proximity-calculation/src/tokenizer"
+	"strings"
+)
+
+const (
+	expressionTypeRange     = "range"
+	expressionTypeTolerance = "tolerance"
+)
+
+var (
+	// rangeSeparators Разделители между границами диапазона: 10-20, 10–20, 10...20, 10÷20
+	rangeSeparators = map[string]bool{
+		"-": true, "–": true, "—": true, "−": true, "~": true, "÷": true, "..": true, "...": true, "…": true,
+	}
+	// toleranceSeparators Разделители между номиналом и допуском: 5±0.2, 5 +/- 0.2
+	toleranceSeparators = map[string]bool{
+		"±": true, "+/-": true, "+-": true,
+	}
+	// rangeWords Словесные диапазоны: открывающее слово (может отсутствовать) и слово между границами
+	rangeWords = []struct {
+		open  string
+		close string
+	}{
+		{"from", "to"},
+		{"", "to"},
+		{"between", "and"},
+		{"от", "до"},
+		{"между", "и"},
+	}
+)
+
+// mergeRanges Функция объединяет последовательности токенов, образующие диапазон или допуск, в один числовой токен
+func mergeRanges(text string, tokens []token) []token {
+	merged := make([]token, 0, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		if current, consumed, ok := matchRange(text, tokens, i); ok {
+			// Открывающее слово ("from", "от") уже добавлено как обычный токен
+			if current.Start < tokens[i].Start {
+				merged = merged[:len(merged)-1]
+			}
+
+			merged = append(merged, current)
+			i += consumed - 1
+			continue
+		}
+
+		merged = append(merged, tokens[i])
+	}
+
+	return merged
+}
+
+// matchRange Функция проверяет, начинается ли с i-го токена диапазон или допуск.
+// Возвращает объединенный токен и количество поглощенных токенов начиная с i-го
+func matchRange(text string, tokens []token, i int) (token, int, bool) {
+	if !tokens[i].isNumber || i+1 >= len(tokens) {
+		return token{}, 0, false
+	}
+
+	first := tokens[i]
+	second := tokens[i+1]
+
+	if second.isNumber {
+		gap := strings.TrimSpace(text[first.End:second.Start])
+
+		if toleranceSeparators[gap] && second.num >= 0 {
+			current := joinTokens(text, first, second, expressionTypeTolerance, first.num-second.num, first.num+second.num)
+			current.hasNominal = true
+			current.num = first.num
+
+			return current, 2, true
+		}
+
+		if rangeSeparators[gap] && first.num <= second.num {
+			return joinTokens(text, first, second, expressionTypeRange, first.num, second.num), 2, true
+		}
+
+		return token{}, 0, false
+	}
+
+	if i+2 >= len(tokens) || !tokens[i+2].isNumber || first.num > tokens[i+2].num {
+		return token{}, 0, false
+	}
+
+	for _, words := range rangeWords {
+		if !strings.EqualFold(second.Text, words.close) || !isBlank(text[first.End:second.Start]) || !isBlank(text[second.End:tokens[i+2].Start]) {
+			continue
+		}
+
+		opening := first
+		if words.open != "" {
+			if i < 1 || !strings.EqualFold(tokens[i-1].Text, words.open) || !isBlank(text[tokens[i-1].End:first.Start]) {
+				continue
+			}
+			opening = tokens[i-1]
+		}
+
+		return joinTokens(text, opening, tokens[i+2], expressionTypeRange, first.num, tokens[i+2].num), 3, true
+	}
+
+	return token{}, 0, false
+}
+
+// joinTokens Функция создает токен диапазона от первого до последнего токена.
+// Диапазон считается записанным словами, если словами записана любая из его границ
+func joinTokens(text string, first token, last token, expressionType string, numMin float64, numMax float64) token {
+	return token{
+		Token: tokenizer.Token{
+			Type:       tokenizer.TypeNumber,
+			Text:       text[first.Start:last.End],
+			Normalized: strings.ToLower(text[first.Start:last.End]),
+			Start:      first.Start,
+			End:        last.End,
+			CharStart:  first.CharStart,
+			CharEnd:    last.CharEnd,
+		},
+		isNumber:       true,
+		isRange:        true,
+		numMin:         helpers.Round(numMin, numbers.Precision),
+		numMax:         helpers.Round(numMax, numbers.Precision),
+		expressionType: expressionType,
+		spelledOut:     first.spelledOut || last.spelledOut,
+	}
+}
+
+func isBlank(gap string) bool {
+	return strings.TrimSpace(gap) == ""
+}
@@ -0,0 +1,72 @@
+package calculator
+
+import "testing"
+
+// tokenizeText Функция разбивает текст на токены так же, как calculateProximity до объединения диапазонов
+func tokenizeText(language string, text string) []token {
+	return mergeSpelledNumbers(text, tokenize(language, text), language)
+}
+
+func TestMergeRanges(t *testing.T) {
+	tests := []struct {
+		text           string
+		language       string
+		expression     string
+		expressionType string
+		numMin         float64
+		numMax         float64
+		hasNominal     bool
+	}{
+		{"длина 10-20 мм", "ru", "10-20", expressionTypeRange, 10, 20, false},
+		{"length 10 – 20 mm", "en", "10 – 20", expressionTypeRange, 10, 20, false},
+		{"length 1.5...2.5 mm", "en", "1.5...2.5", expressionTypeRange, 1.5, 2.5, false},
+		{"heated from 100 to 200 C", "en", "from 100 to 200", expressionTypeRange, 100, 200, false},
+		{"heated 100 to 200 C", "en", "100 to 200", expressionTypeRange, 100, 200, false},
+		{"between 1 and 3 hours", "en", "between 1 and 3", expressionTypeRange, 1, 3, false},
+		{"нагрев от 5 до 10 минут", "ru", "от 5 до 10", expressionTypeRange, 5, 10, false},
+		{"thickness 5±0.2 mm", "en", "5±0.2", expressionTypeTolerance, 4.8, 5.2, true},
+		{"thickness 5 +/- 0.2 mm", "en", "5 +/- 0.2", expressionTypeTolerance, 4.8, 5.2, true},
+		{"from five to ten days", "en", "from five to ten", expressionTypeRange, 5, 10, false},
+	}
+
+	for _, test := range tests {
+		var found *token
+		for _, current := range mergeRanges(test.text, tokenizeText(test.language, test.text)) {
+			if current.isRange {
+				found = &current
+				break
+			}
+		}
+
+		if found == nil {
+			t.Errorf("mergeRanges(%q): диапазон не найден", test.text)
+			continue
+		}
+
+		if found.Text != test.expression || found.expressionType != test.expressionType || found.numMin != test.numMin || found.numMax != test.numMax || found.hasNominal != test.hasNominal {
+			t.Errorf("mergeRanges(%q) = %q %s [%v..%v] номинал %v, ожидалось %q %s [%v..%v] номинал %v", test.text,
+				found.Text, found.expressionType, found.numMin, found.numMax, found.hasNominal,
+				test.expression, test.expressionType, test.numMin, test.numMax, test.hasNominal)
+		}
+	}
+}
+
+func TestMergeRangesSkip(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+	}{
+		{"pages 20-10 of the report", "en"},
+		{"5 and 10 samples", "en"},
+		{"from 5 up to 10", "en"},
+		{"10 -", "en"},
+	}
+
+	for _, test := range tests {
+		for _, current := range mergeRanges(test.text, tokenizeText(test.language, test.text)) {
+			if current.isRange {
+				t.Errorf("mergeRanges(%q): найден диапазон %q", test.text, current.Text)
+			}
+		}
+	}
+}
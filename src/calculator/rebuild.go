@@ -0,0 +1,222 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/logger"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	// generations Физические индексы нового поколения по именам индексов окрестностей (только в режиме пересборки)
+	generations = map[string]string{}
+	// previousAliases Индексы старого поколения по псевдонимам окрестностей на момент запуска (см. checkRebuild)
+	previousAliases = map[string][]string{}
+	// legacyIndices Индексы окрестностей, рассчитанные без пересборки: их имена заняты и становятся псевдонимами
+	legacyIndices = map[string]bool{}
+)
+
+// targetIndex Функция возвращает индекс для записи окрестностей. В режиме пересборки окрестности записываются
+// в новый физический индекс <имя>_<время запуска> (см. elastic.GetProximityGenerationIndexName),
+// а имя индекса окрестностей становится псевдонимом, который переключается после проверки (см. swapGenerations)
+func targetIndex(index string) string {
+	if !config.Rebuild {
+		return index
+	}
+
+	if physical, ok := generations[index]; ok {
+		return physical
+	}
+
+	physical := elastic.GetProximityGenerationIndexName(index, config.Start)
+	generations[index] = physical
+
+	return physical
+}
+
+// checkRebuild Функция до первой записи получает псевдонимы и индексы окрестностей <префикс>*_proximity_*.
+// Индекс, рассчитанный без пересборки, занимает имя псевдонима и может быть заменен только с Config.RebuildDeleteOld,
+// поэтому без этого параметра расчет завершается ошибкой до записи
+func checkRebuild() {
+	pattern := config.ProximityIndexPrefix + "*_proximity_*"
+
+	aliases, err := elastic.GetAliases(client, pattern)
+	if err != nil {
+		logger.Error("Не удалось получить псевдонимы индексов окрестностей: %s", err.Error())
+	}
+	previousAliases = aliases
+
+	indices, err := elastic.GetIndices(client, pattern)
+	if err != nil {
+		logger.Error("Не удалось получить индексы окрестностей: %s", err.Error())
+	}
+
+	for _, index := range indices {
+		if elastic.IsProximityGenerationIndexName(index) {
+			continue
+		}
+
+		if !config.RebuildDeleteOld {
+			logger.Error("Индекс [%s] не является псевдонимом. Для перехода на пересборку укажите -REBUILD_DELETE_OLD", index)
+		}
+
+		legacyIndices[index] = true
+	}
+}
+
+// swapGenerations Функция проверяет индексы нового поколения и одним атомарным запросом переключает на них псевдонимы.
+// Проверяется, что обработаны все документы индекса источника, загрузка прошла без ошибок и количество документов
+// в каждом индексе совпадает с количеством загруженных окрестностей. Если хотя бы одна проверка не пройдена,
+// псевдонимы не переключаются, индексы нового поколения удаляются и читатели продолжают работать со старым поколением.
+// Псевдонимы, для которых не рассчитано новое поколение, не изменяются. Старое поколение удаляется, если включен Config.RebuildDeleteOld
+func swapGenerations() {
+	if err := checkProgress(atomic.LoadInt64(&uploadsDocsTotalCount), totalDocsCount); err != nil {
+		failRebuild(err.Error())
+	}
+
+	if len(generations) == 0 {
+		return
+	}
+
+	aliases := make([]string, 0, len(generations))
+	for alias := range generations {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		physical := generations[alias]
+		stats := elastic.GetBulkIndexer(client, physical).Stats()
+
+		count, err := elastic.CountDocuments(client, physical)
+		if err != nil {
+			failRebuild("не удалось получить количество документов в индексе [" + physical + "]: " + err.Error())
+		}
+
+		if err := checkGeneration(physical, stats.NumIndexed, stats.NumFailed, count); err != nil {
+			failRebuild(err.Error())
+		}
+
+		logger.Info(fmt.Sprintf("Индекс [%s] прошел проверку: %d документов", physical, count))
+	}
+
+	actions, oldIndices := buildSwapActions(aliases)
+
+	if err := elastic.UpdateAliases(client, actions); err != nil {
+		failRebuild("не удалось переключить псевдонимы: " + err.Error())
+	}
+
+	for _, alias := range aliases {
+		logger.Info(fmt.Sprintf("Псевдоним [%s] переключен на индекс [%s]", alias, generations[alias]))
+	}
+
+	warnStale()
+
+	if !config.RebuildDeleteOld || len(oldIndices) == 0 {
+		return
+	}
+
+	if err := elastic.DeleteIndices(client, oldIndices); err != nil {
+		logger.Error("Не удалось удалить индексы старого поколения: %s", err.Error())
+	}
+
+	for _, index := range oldIndices {
+		logger.Info("Удален индекс старого поколения [%s]", index)
+	}
+}
+
+// checkProgress Функция проверяет, что обработаны все документы индекса источника, а пустой расчет
+// не заменяет существующее старое поколение
+func checkProgress(processed int64, total int64) error {
+	if processed != total {
+		return fmt.Errorf("обработано %d документов из %d документов индекса источника", processed, total)
+	}
+
+	if len(generations) == 0 && (len(previousAliases) > 0 || len(legacyIndices) > 0) {
+		return errors.New("не рассчитано ни одной окрестности, а старое поколение индексов существует")
+	}
+
+	return nil
+}
+
+// checkGeneration Функция проверяет, что загрузка в индекс нового поколения прошла без ошибок
+// и количество документов в нем совпадает с количеством загруженных окрестностей
+func checkGeneration(physical string, indexed uint64, failed uint64, count int64) error {
+	if failed > 0 || uint64(count) != indexed {
+		return fmt.Errorf("индекс [%s]: загружено %d окрестностей, ошибок %d, в индексе %d документов", physical, indexed, failed, count)
+	}
+
+	return nil
+}
+
+// buildSwapActions Функция строит действия атомарного переключения псевдонимов aliases на индексы нового поколения
+// и возвращает индексы старого поколения, с которых псевдонимы снимаются
+func buildSwapActions(aliases []string) ([]interface{}, []string) {
+	var actions []interface{}
+	var oldIndices []string
+
+	for _, alias := range aliases {
+		// Индекс, рассчитанный без пересборки, занимает имя псевдонима и удаляется вместе с переключением
+		if legacyIndices[alias] {
+			actions = append(actions, map[string]interface{}{
+				"remove_index": map[string]interface{}{"index": alias},
+			})
+		}
+
+		for _, index := range previousAliases[alias] {
+			actions = append(actions, map[string]interface{}{
+				"remove": map[string]interface{}{"index": index, "alias": alias},
+			})
+			oldIndices = append(oldIndices, index)
+		}
+
+		actions = append(actions, map[string]interface{}{
+			"add": map[string]interface{}{"index": generations[alias], "alias": alias},
+		})
+	}
+
+	return actions, oldIndices
+}
+
+// warnStale Функция предупреждает о псевдонимах и индексах окрестностей, для которых в этом расчете не рассчитано новое поколение
+// (например, размерность окрестности убрана из -PROXIMITY_AMBIT). Они не изменяются и не удаляются
+func warnStale() {
+	var stale []string
+	for alias := range previousAliases {
+		if _, ok := generations[alias]; !ok {
+			stale = append(stale, alias)
+		}
+	}
+	for index := range legacyIndices {
+		if _, ok := generations[index]; !ok {
+			stale = append(stale, index)
+		}
+	}
+	sort.Strings(stale)
+
+	if len(stale) > 0 {
+		logger.Warning("Для индексов окрестностей не рассчитано новое поколение, они оставлены без изменений: %s", strings.Join(stale, ", "))
+	}
+}
+
+// failRebuild Функция удаляет индексы нового поколения и завершает расчет ошибкой. Псевдонимы при этом не изменяются
+func failRebuild(reason string) {
+	indices := make([]string, 0, len(generations))
+	for _, physical := range generations {
+		indices = append(indices, physical)
+	}
+	sort.Strings(indices)
+
+	if len(indices) > 0 {
+		if err := elastic.DeleteIndices(client, indices); err != nil {
+			logger.Warning("Не удалось удалить индексы нового поколения: %s", err.Error())
+		} else {
+			logger.Info("Удалены индексы нового поколения: %s", strings.Join(indices, ", "))
+		}
+	}
+
+	logger.Error("Пересборка не прошла проверку, псевдонимы не переключены: %s", reason)
+}
@@ -0,0 +1,111 @@
+package calculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+// setGenerations Функция подменяет состояние пересборки на время теста
+func setGenerations(t *testing.T, current map[string]string, previous map[string][]string, legacy map[string]bool) {
+	g, p, l := generations, previousAliases, legacyIndices
+	t.Cleanup(func() { generations, previousAliases, legacyIndices = g, p, l })
+
+	generations, previousAliases, legacyIndices = current, previous, legacy
+}
+
+func TestBuildSwapActions(t *testing.T) {
+	setGenerations(t,
+		map[string]string{
+			"doc_proximity_words_5": "doc_proximity_words_5_20260101000000",
+			"doc_proximity_chars_9": "doc_proximity_chars_9_20260101000000",
+		},
+		map[string][]string{
+			"doc_proximity_words_5": {"doc_proximity_words_5_20250101000000"},
+		},
+		map[string]bool{"doc_proximity_chars_9": true},
+	)
+
+	actions, oldIndices := buildSwapActions([]string{"doc_proximity_chars_9", "doc_proximity_words_5"})
+
+	expected := []interface{}{
+		map[string]interface{}{"remove_index": map[string]interface{}{"index": "doc_proximity_chars_9"}},
+		map[string]interface{}{"add": map[string]interface{}{"index": "doc_proximity_chars_9_20260101000000", "alias": "doc_proximity_chars_9"}},
+		map[string]interface{}{"remove": map[string]interface{}{"index": "doc_proximity_words_5_20250101000000", "alias": "doc_proximity_words_5"}},
+		map[string]interface{}{"add": map[string]interface{}{"index": "doc_proximity_words_5_20260101000000", "alias": "doc_proximity_words_5"}},
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("buildSwapActions() = %v, ожидалось %v", actions, expected)
+	}
+
+	if !reflect.DeepEqual(oldIndices, []string{"doc_proximity_words_5_20250101000000"}) {
+		t.Errorf("buildSwapActions() старое поколение = %v", oldIndices)
+	}
+}
+
+func TestCheckGeneration(t *testing.T) {
+	tests := []struct {
+		indexed uint64
+		failed  uint64
+		count   int64
+		ok      bool
+	}{
+		{10, 0, 10, true},
+		{0, 0, 0, true},
+		{10, 0, 9, false},
+		{10, 1, 10, false},
+		{9, 0, 10, false},
+	}
+
+	for _, test := range tests {
+		err := checkGeneration("doc_proximity_words_5_20260101000000", test.indexed, test.failed, test.count)
+		if (err == nil) != test.ok {
+			t.Errorf("checkGeneration(%d, %d, %d) = %v, ожидалось ok=%v", test.indexed, test.failed, test.count, err, test.ok)
+		}
+	}
+}
+
+func TestCheckProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		processed int64
+		total     int64
+		current   map[string]string
+		previous  map[string][]string
+		legacy    map[string]bool
+		ok        bool
+	}{
+		{"все документы", 5, 5, map[string]string{"a": "a_1"}, nil, nil, true},
+		{"не все документы", 4, 5, map[string]string{"a": "a_1"}, nil, nil, false},
+		{"пустой первый расчет", 0, 0, map[string]string{}, map[string][]string{}, map[string]bool{}, true},
+		{"пустой расчет при старом поколении", 0, 0, map[string]string{}, map[string][]string{"a": {"a_0"}}, map[string]bool{}, false},
+		{"пустой расчет при старом индексе", 0, 0, map[string]string{}, map[string][]string{}, map[string]bool{"a": true}, false},
+	}
+
+	for _, test := range tests {
+		setGenerations(t, test.current, test.previous, test.legacy)
+
+		if err := checkProgress(test.processed, test.total); (err == nil) != test.ok {
+			t.Errorf("checkProgress(%s) = %v, ожидалось ok=%v", test.name, err, test.ok)
+		}
+	}
+}
+
+func TestTargetIndex(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	setGenerations(t, map[string]string{}, map[string][]string{}, map[string]bool{})
+
+	config = Config{}
+	if index := targetIndex("doc_proximity_words_5"); index != "doc_proximity_words_5" {
+		t.Errorf("targetIndex() без пересборки = %q", index)
+	}
+
+	config = Config{Rebuild: true}
+	first := targetIndex("doc_proximity_words_5")
+	if first == "doc_proximity_words_5" || generations["doc_proximity_words_5"] != first {
+		t.Errorf("targetIndex() при пересборке = %q, поколения %v", first, generations)
+	}
+
+	if second := targetIndex("doc_proximity_words_5"); second != first {
+		t.Errorf("targetIndex() повторно = %q, ожидалось %q", second, first)
+	}
+}
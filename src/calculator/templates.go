@@ -0,0 +1,145 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/structs"
+	"errors"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"sort"
+	"strings"
+)
+
+// templatePriority Базовый приоритет шаблонов индексов окрестностей (см. getTemplatePriority)
+const templatePriority = 100
+
+// checkedIndices Индексы окрестностей, проверенные перед первой записью (см. checkTargetIndex)
+var checkedIndices = map[string]bool{}
+
+// installTemplates Функция до первой записи устанавливает шаблоны индексов окрестностей: общий шаблон <префикс>*_proximity_*
+// и шаблоны <префикс><язык>_proximity_* с анализатором языка для токенов соседей (см. Config.LanguageAnalyzers).
+// Шаблон, установленный для другого формата записи соседей (_meta.layout), не заменяется - расчет завершается ошибкой
+func installTemplates() {
+	templates := []elastic.IndexTemplate{
+		buildTemplate(encoder, config.ProximityIndexPrefix+"proximity_default", config.ProximityIndexPrefix+"*_proximity_*", ""),
+	}
+
+	languages := make([]string, 0, len(config.LanguageAnalyzers))
+	for language := range config.LanguageAnalyzers {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	for _, language := range languages {
+		prefix := config.ProximityIndexPrefix + language + "_proximity"
+		templates = append(templates, buildTemplate(encoder, prefix, prefix+"_*", config.LanguageAnalyzers[language]))
+	}
+
+	for _, template := range templates {
+		if err := checkTemplateLayout(template.Name); err != nil {
+			logger.Error("Проверка шаблона индекса не пройдена: %s", err.Error())
+		}
+
+		if err := elastic.PutIndexTemplate(client, template); err != nil {
+			logger.Error("Не удалось установить шаблон индекса ["+template.Name+"]: %s", err.Error())
+		}
+
+		logger.Info("Установлен шаблон индекса [%s]", template.Name)
+	}
+}
+
+// checkTemplateLayout Функция проверяет, что установленный шаблон индекса отсутствует или установлен для текущего формата записи соседей
+func checkTemplateLayout(name string) error {
+	layout, err := elastic.GetIndexTemplateMeta(client, name, "layout")
+	if err != nil {
+		return errors.New("не удалось получить шаблон индекса [" + name + "]: " + err.Error())
+	}
+
+	if layout != "" && layout != config.Layout {
+		return fmt.Errorf("шаблон индекса [%s] установлен для формата записи соседей %s, индексы другого формата записываются с другим -TARGET_INDEX_PREFIX", name, layout)
+	}
+
+	return nil
+}
+
+// buildTemplate Функция строит шаблон индекса. Версия маппинга и формат записи соседей сохраняются и в _meta шаблона,
+// и в _meta маппинга, чтобы по индексу было видно, создан ли он по шаблону (см. checkTargetIndex)
+func buildTemplate(encoder structs.Encoder, name string, pattern string, analyzer string) elastic.IndexTemplate {
+	settings := map[string]interface{}{
+		"number_of_shards":   config.IndexShards,
+		"number_of_replicas": config.IndexReplicas,
+	}
+	for key, value := range encoder.Settings() {
+		settings[key] = value
+	}
+
+	mappings := encoder.Mappings(analyzer)
+	mappings["_meta"] = map[string]interface{}{
+		"mapping_version": structs.MappingVersion,
+		"layout":          config.Layout,
+	}
+
+	return elastic.IndexTemplate{
+		Name:     name,
+		Patterns: []string{pattern},
+		Priority: getTemplatePriority(pattern),
+		Version:  structs.MappingVersion,
+		Settings: settings,
+		Mappings: mappings,
+		Meta: map[string]interface{}{
+			"schema_version": structs.SchemaVersion,
+			"layout":         config.Layout,
+		},
+	}
+}
+
+// getTemplatePriority Функция возвращает приоритет шаблона: базовый приоритет плюс количество символов шаблона имени без "*".
+// Более конкретный шаблон получает больший приоритет, поэтому шаблоны запусков с пересекающимися префиксами
+// (p_ и p_en_) не совпадают по приоритету, и Elasticsearch их принимает
+func getTemplatePriority(pattern string) int {
+	return templatePriority + len(strings.ReplaceAll(pattern, "*", ""))
+}
+
+// checkTargetIndex Функция перед первой записью в существующий индекс окрестностей проверяет, что он создан по шаблону
+// текущего формата записи соседей. Индекс без _meta в маппинге (создан с динамическим маппингом до установки шаблонов)
+// или индекс другого формата не дополняется - расчет завершается ошибкой. Индекс более старой версии маппинга
+// дополняется с предупреждением
+func checkTargetIndex(index string) {
+	if checkedIndices[index] {
+		return
+	}
+	checkedIndices[index] = true
+
+	meta, exists, err := elastic.GetIndexMappingMeta(client, index)
+	if err != nil {
+		logger.Error("Не удалось получить маппинг индекса ["+index+"]: %s", err.Error())
+	}
+
+	if !exists {
+		return
+	}
+
+	outdated, err := checkIndexMeta(index, meta)
+	if err != nil {
+		logger.Error("Проверка индекса окрестностей не пройдена: %s", err.Error())
+	}
+
+	if outdated {
+		logger.Warning("Индекс окрестностей [%s] создан по шаблону более старой версии маппинга: новые поля могут быть проиндексированы динамически, рекомендуется пересчет с -REBUILD", index)
+	}
+}
+
+// checkIndexMeta Функция проверяет _meta маппинга существующего индекса окрестностей (см. checkTargetIndex)
+// и возвращает признак более старой версии маппинга
+func checkIndexMeta(index string, meta gjson.Result) (bool, error) {
+	if !meta.Get("mapping_version").Exists() {
+		return false, errors.New("индекс окрестностей [" + index + "] создан без шаблона (динамический маппинг): удалите его или пересчитайте с -REBUILD")
+	}
+
+	if layout := meta.Get("layout").String(); layout != config.Layout {
+		return false, fmt.Errorf("индекс окрестностей [%s] создан для формата записи соседей %s: удалите его или пересчитайте с -REBUILD", index, layout)
+	}
+
+	return int(meta.Get("mapping_version").Int()) < structs.MappingVersion, nil
+}
@@ -0,0 +1,140 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// useTestClient Функция на время теста подменяет клиент Elasticsearch: запросы обрабатывает handler.
+// Запрос информации о кластере, которым клиент проверяет продукт, обрабатывается здесь же
+func useTestClient(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/" {
+			w.Write([]byte(`{"version": {"number": "7.17.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`))
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	previous := client
+	t.Cleanup(func() { client = previous })
+
+	var err error
+	client, err = elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("elasticsearch.NewClient() = %v", err)
+	}
+}
+
+func TestGetTemplatePriority(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected int
+	}{
+		{"p_*_proximity_*", 100 + len("p__proximity_")},
+		{"p_en_proximity_*", 100 + len("p_en_proximity_")},
+		{"*_proximity_*", 100 + len("_proximity_")},
+	}
+
+	for _, test := range tests {
+		if actual := getTemplatePriority(test.pattern); actual != test.expected {
+			t.Errorf("getTemplatePriority(%q) = %d, ожидалось %d", test.pattern, actual, test.expected)
+		}
+	}
+
+	if getTemplatePriority("p_en_proximity_*") <= getTemplatePriority("p_*_proximity_*") {
+		t.Errorf("getTemplatePriority(): шаблон языка должен иметь больший приоритет, чем общий шаблон")
+	}
+
+	if getTemplatePriority("p_*_proximity_*") == getTemplatePriority("p_en_*_proximity_*") {
+		t.Errorf("getTemplatePriority(): шаблоны пересекающихся префиксов не должны совпадать по приоритету")
+	}
+}
+
+func TestBuildTemplate(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Layout: structs.LayoutNested, IndexShards: 1, IndexReplicas: 0}
+
+	template := buildTemplate(structs.NestedEncoder{}, "p_proximity_default", "p_*_proximity_*", "english")
+
+	meta, ok := template.Mappings["_meta"].(map[string]interface{})
+	if !ok || meta["layout"] != structs.LayoutNested || meta["mapping_version"] != structs.MappingVersion {
+		t.Errorf("buildTemplate() _meta маппинга = %v", template.Mappings["_meta"])
+	}
+
+	if template.Meta["layout"] != structs.LayoutNested || template.Version != structs.MappingVersion {
+		t.Errorf("buildTemplate() _meta шаблона = %v, версия %d", template.Meta, template.Version)
+	}
+
+	if template.Priority != getTemplatePriority("p_*_proximity_*") {
+		t.Errorf("buildTemplate() приоритет = %d", template.Priority)
+	}
+}
+
+func TestCheckTemplateLayout(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Layout: structs.LayoutFlat}
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		ok     bool
+	}{
+		{"шаблон не установлен", http.StatusNotFound, `{}`, true},
+		{"шаблон текущего формата", http.StatusOK, templateResponse(structs.LayoutFlat), true},
+		{"шаблон другого формата", http.StatusOK, templateResponse(structs.LayoutNested), false},
+		{"шаблон без формата", http.StatusOK, `{"index_templates": [{"name": "p_proximity_default", "index_template": {}}]}`, true},
+		{"ошибка Elasticsearch", http.StatusInternalServerError, `{"error": "internal"}`, false},
+	}
+
+	for _, test := range tests {
+		useTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		})
+
+		if err := checkTemplateLayout("p_proximity_default"); (err == nil) != test.ok {
+			t.Errorf("checkTemplateLayout(%s) = %v, ожидалось ok=%v", test.name, err, test.ok)
+		}
+	}
+}
+
+// templateResponse Функция возвращает ответ Elasticsearch на запрос шаблона, установленного для формата layout
+func templateResponse(layout string) string {
+	return fmt.Sprintf(`{"index_templates": [{"name": "p_proximity_default", "index_template": {"_meta": {"layout": %q}}}]}`, layout)
+}
+
+func TestCheckIndexMeta(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{Layout: structs.LayoutFlat}
+
+	tests := []struct {
+		meta     string
+		outdated bool
+		ok       bool
+	}{
+		{fmt.Sprintf(`{"mapping_version": %d, "layout": "flat"}`, structs.MappingVersion), false, true},
+		{`{"mapping_version": 1, "layout": "flat"}`, true, true},
+		{fmt.Sprintf(`{"mapping_version": %d, "layout": "nested"}`, structs.MappingVersion), false, false},
+		{`{"layout": "flat"}`, false, false},
+		{``, false, false},
+	}
+
+	for _, test := range tests {
+		outdated, err := checkIndexMeta("p_en_proximity_words_5", gjson.Parse(test.meta))
+		if outdated != test.outdated || (err == nil) != test.ok {
+			t.Errorf("checkIndexMeta(%s) = %v, %v, ожидалось %v, ok=%v", test.meta, outdated, err, test.outdated, test.ok)
+		}
+	}
+}
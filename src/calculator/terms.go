@@ -0,0 +1,92 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"strings"
+)
+
+const AnchorTypeTerm = "term"
+
+// termWords Слова терминов словаря, сгруппированные по первому слову: "melting point" -> terms["melting"]
+var termWords map[string][][]string
+
+// initTerms Функция подготавливает словарь терминов-якорей (config.AnchorTerms) для поиска в токенах
+func initTerms() {
+	termWords = map[string][][]string{}
+
+	for _, term := range config.AnchorTerms {
+		words := strings.Fields(strings.ToLower(term))
+		if len(words) == 0 {
+			continue
+		}
+
+		termWords[words[0]] = append(termWords[words[0]], words)
+	}
+}
+
+// matchTerm Функция проверяет, начинается ли с i-го токена термин словаря, и возвращает термин и количество его токенов.
+// Из нескольких подходящих терминов выбирается самый длинный
+func matchTerm(tokens []token, i int) (string, int) {
+	if tokens[i].isNumber {
+		return "", 0
+	}
+
+	var (
+		term   string
+		length int
+	)
+
+	for _, words := range termWords[tokens[i].Normalized] {
+		if len(words) <= length || i+len(words) > len(tokens) {
+			continue
+		}
+
+		matched := true
+		for k := 1; k < len(words); k++ {
+			if tokens[i+k].isNumber || tokens[i+k].Normalized != words[k] {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			term, length = strings.Join(words, " "), len(words)
+		}
+	}
+
+	return term, length
+}
+
+// createTermProximity Функция создает окрестность термина словаря без соседей
+func createTermProximity(sourceDocId string, sourceField string, term string, expression string) structs.Proximity {
+	return structs.CreateAnchorProximityObject(config.SourceIndex, sourceDocId, sourceField, term, AnchorTypeTerm, expression)
+}
+
+// getNumbers Функция возвращает числа среди соседей якоря с их расстоянием со знаком (отрицательное - число слева).
+// Шумовые числа (ссылки на рисунки, пункты формулы и т.п., см. detectNoise) значениями рядом с якорем не считаются
+func getNumbers(neighbours []neighbour, noise []*NoiseRule) []structs.Number {
+	numbers := []structs.Number{}
+
+	for _, n := range neighbours {
+		if !n.token.isNumber || noise[n.index] != nil {
+			continue
+		}
+
+		number := structs.Number{Distance: n.distance}
+		if hasSingleNumber(n.token) {
+			num := n.token.num
+			number.Num = &num
+		}
+
+		if n.token.isRange {
+			numMin, numMax := n.token.numMin, n.token.numMax
+			number.NumMin, number.NumMax = &numMin, &numMax
+			number.Expression = n.token.Text
+		}
+		setUnit(&number.Value, n.token)
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
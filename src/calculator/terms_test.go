@@ -0,0 +1,72 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"testing"
+)
+
+// setTerms Функция на время теста подменяет словарь терминов-якорей
+func setTerms(t *testing.T, terms ...string) {
+	previousConfig, previousTerms := config, termWords
+	t.Cleanup(func() { config, termWords = previousConfig, previousTerms })
+
+	config.AnchorTerms = terms
+	initTerms()
+}
+
+func TestMatchTerm(t *testing.T) {
+	setTerms(t, "melting point", "Melting point of steel", "point", "  ")
+
+	tests := []struct {
+		text   string
+		i      int
+		term   string
+		length int
+	}{
+		{"the melting point is 1500", 1, "melting point", 2},
+		{"Melting Point of steel is 1500", 0, "melting point of steel", 4},
+		{"melting point of iron", 0, "melting point", 2},
+		{"the point is", 1, "point", 1},
+		{"melting 5 point", 0, "", 0},
+		{"melting", 0, "", 0},
+		{"heat", 0, "", 0},
+	}
+
+	for _, test := range tests {
+		tokens := tokenize("en", test.text)
+		if term, length := matchTerm(tokens, test.i); term != test.term || length != test.length {
+			t.Errorf("matchTerm(%q, %d) = %q, %d, ожидалось %q, %d", test.text, test.i, term, length, test.term, test.length)
+		}
+	}
+}
+
+func TestCalculateTermProximity(t *testing.T) {
+	setTerms(t, "melting point")
+	config.Windows = []Window{{3, 5}}
+	config.WindowStrategy = structs.StrategyTokens
+
+	result := calculate(t, "text", "en", "the Melting Point is 1500 or 1600 K")
+
+	var term *structs.Proximity
+	for _, p := range result[Window{3, 5}.IndexName("en")] {
+		if p.IsAnchor() {
+			term = p
+		}
+	}
+
+	if term == nil {
+		t.Fatalf("calculateProximity(): окрестность термина не найдена")
+	}
+
+	if term.Anchor != "melting point" || term.AnchorType != AnchorTypeTerm || term.Expression != "Melting Point" {
+		t.Errorf("окрестность термина = %q, %q, %q", term.Anchor, term.AnchorType, term.Expression)
+	}
+
+	if before := neighbourTexts(term.Before); len(before) != 1 || before[0] != "the" {
+		t.Errorf("соседи слева = %v, ожидалось [the]", before)
+	}
+
+	if len(term.Numbers) != 2 || *term.Numbers[0].Num != 1500 || term.Numbers[0].Distance != 2 || *term.Numbers[1].Num != 1600 || term.Numbers[1].Distance != 4 {
+		t.Errorf("числа вокруг термина = %+v", term.Numbers)
+	}
+}
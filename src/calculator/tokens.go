@@ -0,0 +1,82 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/numbers"
+	"elastic-proximity-calculation/src/tokenizer"
+	"elastic-proximity-calculation/src/units"
+)
+
+// token Токен текста с результатом разбора числа
+type token struct {
+	tokenizer.Token
+
+	isNumber bool
+	num      float64
+	// literal Точность записи числа в тексте (см. numbers.Describe)
+	literal    numbers.Literal
+	hasLiteral bool
+	// spelledOut Число записано словами: "three", "сто двадцать"
+	spelledOut bool
+
+	// Заполняются для диапазонов (10-20, от 5 до 10) и допусков (5±0.2)
+	isRange        bool
+	hasNominal     bool
+	numMin         float64
+	numMax         float64
+	expressionType string
+
+	// Заполняются, если сразу после числа стоит единица измерения
+	hasUnit bool
+	unit    units.Unit
+
+	// anchorType Имя именованного выражения для токенов типа TypeAnchor
+	anchorType string
+	// date Дата в формате ISO 8601 для якорей типа AnchorTypeDate
+	date string
+}
+
+// getTokenizer Функция возвращает токенизатор языка: указанный в LanguageTokenizers или токенизатор по умолчанию.
+// Если токенизатор по умолчанию не указан, используется tokenizer.NameRegex
+func getTokenizer(language string) tokenizer.Tokenizer {
+	if cached, ok := tokenizers.Load(language); ok {
+		return cached.(tokenizer.Tokenizer)
+	}
+
+	name, ok := config.LanguageTokenizers[language]
+	if !ok {
+		name = config.Tokenizer
+	}
+	if name == "" {
+		name = tokenizer.NameRegex
+	}
+
+	created, err := tokenizer.New(name, language)
+	if err != nil {
+		logger.Error("Не удалось создать токенизатор для языка [" + language + "]: " + err.Error())
+	}
+
+	actual, _ := tokenizers.LoadOrStore(language, created)
+
+	return actual.(tokenizer.Tokenizer)
+}
+
+// tokenize Функция разбивает текст на токены и разбирает числа с учетом правил записи чисел языка
+func tokenize(language string, text string) []token {
+	parts := getTokenizer(language).Tokenize(text)
+	tokens := make([]token, 0, len(parts))
+
+	for _, part := range parts {
+		current := token{Token: part}
+		if part.Type == tokenizer.TypeNumber {
+			current.num, current.isNumber = numbers.Parse(part.Text, language)
+			if current.isNumber {
+				current.literal, current.hasLiteral = numbers.Describe(part.Text, language)
+			}
+		}
+
+		tokens = append(tokens, current)
+	}
+
+	return tokens
+}
@@ -0,0 +1,68 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/tokenizer"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// wordsTokenizer Токенизатор для тестов: все токены по пробелам - слова, числа не разбираются
+type wordsTokenizer struct{}
+
+func (wordsTokenizer) Tokenize(text string) []tokenizer.Token {
+	var tokens []tokenizer.Token
+	for _, field := range strings.Fields(text) {
+		tokens = append(tokens, tokenizer.Token{Type: tokenizer.TypeWord, Text: field, Normalized: field})
+	}
+
+	return tokens
+}
+
+func TestGetTokenizer(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	defer tokenizers.Delete("xx")
+	defer tokenizers.Delete("yy")
+
+	created := 0
+	tokenizer.Register("test-words", func(language string) tokenizer.Tokenizer {
+		created++
+		return wordsTokenizer{}
+	})
+	config = Config{LanguageTokenizers: map[string]string{"xx": "test-words"}}
+
+	if tokens := tokenize("xx", "heated to 150"); len(tokens) != 3 || tokens[2].isNumber {
+		t.Errorf("tokenize(xx) = %+v, ожидалось три слова без чисел", tokens)
+	}
+
+	if tokens := tokenize("yy", "heated to 150"); len(tokens) != 3 || !tokens[2].isNumber || tokens[2].num != 150 {
+		t.Errorf("tokenize(yy) = %+v, ожидалось число 150 токенизатора по умолчанию", tokens)
+	}
+
+	getTokenizer("xx")
+	if created != 1 {
+		t.Errorf("getTokenizer(xx) создал токенизатор %d раз, ожидалось один раз", created)
+	}
+}
+
+func TestCalculateProximityCJK(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	defer tokenizers.Delete("zh")
+	config = Config{Windows: []Window{{2, 2}}, LanguageTokenizers: map[string]string{"zh": tokenizer.NameCJK}}
+	tokenizers.Delete("zh")
+
+	result := calculate(t, "text", "zh", "加热温度为20℃，厚度为5mm")
+
+	found := result[Window{2, 2}.IndexName("zh")]
+	if len(found) != 2 {
+		t.Fatalf("calculateProximity() = %d окрестностей, ожидалось 2", len(found))
+	}
+
+	if before := neighbourTexts(found[0].Before); *found[0].Num != 20 || !reflect.DeepEqual(before, []string{"为", "温度"}) {
+		t.Errorf("окрестность числа 20: соседи слева %v", before)
+	}
+
+	if *found[1].Num != 5 || found[1].Value.Unit != "mm" {
+		t.Errorf("окрестность числа 5 = %v %q, ожидалось 5 mm", *found[1].Num, found[1].Value.Unit)
+	}
+}
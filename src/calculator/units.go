@@ -0,0 +1,68 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"elastic-proximity-calculation/src/units"
+	"strings"
+)
+
+// unitGaps Допустимые разделители между числом и единицей измерения: 5mm, 5 mm, 5-mm
+var unitGaps = []string{"", " ", " ", " ", "-"}
+
+// attachUnits Функция определяет единицы измерения, стоящие сразу после чисел.
+// Через разделитель не присоединяются единицы, которые могут оказаться другим словом (см. units.IsAmbiguous): "10 с водой",
+// и "г." после года: "2019 г.".
+// Токены единиц измерения остаются в тексте и участвуют в окрестности как обычные слова
+func attachUnits(text string, tokens []token) []token {
+	for i := range tokens {
+		if !tokens[i].isNumber {
+			continue
+		}
+
+		rest := text[tokens[i].End:]
+		for _, gap := range unitGaps {
+			if !strings.HasPrefix(rest, gap) {
+				continue
+			}
+
+			if unit, length, ok := units.Match(rest[len(gap):]); ok {
+				variant, after := rest[len(gap):len(gap)+length], rest[len(gap)+length:]
+				if gap != "" && (units.IsAmbiguous(variant, after) || isYearAbbreviation(tokens[i], variant, after)) {
+					break
+				}
+
+				tokens[i].unit = unit
+				tokens[i].hasUnit = true
+				break
+			}
+		}
+	}
+
+	return tokens
+}
+
+// isYearAbbreviation Функция проверяет, что "г." после числа - сокращение слова "год", а не граммы: "2019 г."
+func isYearAbbreviation(current token, variant string, after string) bool {
+	return variant == "г" && strings.HasPrefix(after, ".") && len(current.Text) == 4 &&
+		current.num >= minPlausibleYear && current.num <= maxPlausibleYear && current.num == float64(int(current.num))
+}
+
+// setUnit Функция добавляет в окрестность единицу измерения и значения, приведенные к СИ
+func setUnit(value *structs.Value, t token) {
+	if !t.hasUnit {
+		return
+	}
+
+	value.Unit = t.unit.Symbol
+	value.UnitCategory = t.unit.Category
+
+	if hasSingleNumber(t) {
+		numSI := t.unit.ToSI(t.num)
+		value.NumSI = &numSI
+	}
+
+	if t.isRange {
+		numMinSI, numMaxSI := t.unit.ToSI(t.numMin), t.unit.ToSI(t.numMax)
+		value.NumMinSI, value.NumMaxSI = &numMinSI, &numMaxSI
+	}
+}
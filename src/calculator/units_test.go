@@ -0,0 +1,44 @@
+package calculator
+
+import "testing"
+
+func TestAttachUnits(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		symbol   string
+	}{
+		{"thickness 5mm", "en", "mm"},
+		{"thickness 5 mm", "en", "mm"},
+		{"thickness 5-mm layer", "en", "mm"},
+		{"давление 10 МПа", "ru", "MPa"},
+		{"length 5m", "en", "m"},
+		{"length 5 m", "en", "m"},
+		{"в 2019 г.", "ru", ""},
+		{"масса 5г", "ru", "g"},
+		{"масса 5 г", "ru", "g"},
+		{"добавили 5 г.", "ru", "g"},
+		{"длина 3 м", "ru", "m"},
+		{"выдержка 10 с", "ru", "s"},
+		{"смешать 10 с водой", "ru", ""},
+		{"при 300 K", "en", "K"},
+		{"melting point 5 m.p.", "en", ""},
+		{"heated to 100 °C", "en", "°C"},
+		{"5 mmHg", "en", ""},
+	}
+
+	for _, test := range tests {
+		tokens := attachUnits(test.text, tokenizeText(test.language, test.text))
+
+		symbol := ""
+		for _, current := range tokens {
+			if current.isNumber && current.hasUnit {
+				symbol = current.unit.Symbol
+			}
+		}
+
+		if symbol != test.symbol {
+			t.Errorf("attachUnits(%q): единица %q, ожидалось %q", test.text, symbol, test.symbol)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/structs"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Window Размерность окрестности: количество токенов слева и справа от числа
+type Window struct {
+	Left  int
+	Right int
+}
+
+// ParseWindow Функция для разбора размерности окрестности вида "15" (одинаково с обеих сторон) или "5:15" (слева:справа)
+func ParseWindow(s string) (Window, error) {
+	parts := strings.SplitN(s, ":", 2)
+
+	left, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Window{}, errors.New("некорректная размерность окрестности: " + s)
+	}
+
+	right := left
+	if len(parts) == 2 {
+		if right, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+			return Window{}, errors.New("некорректная размерность окрестности: " + s)
+		}
+	}
+
+	if left < 0 || right < 0 || left+right == 0 {
+		return Window{}, errors.New("размерность окрестности должна быть неотрицательной и ненулевой: " + s)
+	}
+
+	return Window{Left: left, Right: right}, nil
+}
+
+// ParseWindowList Функция для разбора списка размерностей окрестности через запятую: "5,15,30" или "5:15,30".
+// Повторяющиеся размерности отбрасываются
+func ParseWindowList(s string) ([]Window, error) {
+	var windows []Window
+
+	for _, part := range strings.Split(s, ",") {
+		window, err := ParseWindow(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if !containsWindow(windows, window) {
+			windows = append(windows, window)
+		}
+	}
+
+	return windows, nil
+}
+
+func containsWindow(windows []Window, window Window) bool {
+	for _, w := range windows {
+		if w == window {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseWindows Функция для разбора размерностей окрестности вида "ключ=размерность" (см. helpers.ParseMap и ParseWindow)
+func ParseWindows(m map[string]string) (map[string]Window, error) {
+	windows := map[string]Window{}
+
+	for key, value := range m {
+		window, err := ParseWindow(value)
+		if err != nil {
+			return nil, errors.New(key + ": " + err.Error())
+		}
+
+		windows[key] = window
+	}
+
+	return windows, nil
+}
+
+// String Функция возвращает размерность окрестности в формате ParseWindow
+func (w Window) String() string {
+	if w.Left == w.Right {
+		return strconv.Itoa(w.Left)
+	}
+
+	return strconv.Itoa(w.Left) + ":" + strconv.Itoa(w.Right)
+}
+
+// IndexName Функция возвращает имя индекса окрестностей языка для данной размерности окрестности
+func (w Window) IndexName(language string) string {
+	return elastic.GetProximityWindowIndexName(config.ProximityIndexPrefix, language, structs.StrategyIndexInfix(config.WindowStrategy), w.Left, w.Right)
+}
+
+// getWindows Функция возвращает размерности окрестности для поля и языка.
+// Размерность поля важнее размерности языка, размерность языка важнее общего списка размерностей
+func getWindows(sourceField string, language string) []Window {
+	if window, ok := config.FieldWindows[sourceField]; ok {
+		return []Window{window}
+	}
+
+	if window, ok := config.LanguageWindows[language]; ok {
+		return []Window{window}
+	}
+
+	return config.Windows
+}
@@ -0,0 +1,105 @@
+package calculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Window
+		ok       bool
+	}{
+		{"15", Window{15, 15}, true},
+		{"5:15", Window{5, 15}, true},
+		{" 5 : 15 ", Window{5, 15}, true},
+		{"0:10", Window{0, 10}, true},
+		{"0", Window{}, false},
+		{"0:0", Window{}, false},
+		{"-1:5", Window{}, false},
+		{"5:", Window{}, false},
+		{"a", Window{}, false},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseWindow(test.input)
+		if (err == nil) != test.ok || actual != test.expected {
+			t.Errorf("ParseWindow(%q) = %v, %v, ожидалось %v", test.input, actual, err, test.expected)
+		}
+
+		if test.ok {
+			if again, _ := ParseWindow(actual.String()); again != actual {
+				t.Errorf("ParseWindow(%q.String()) = %v, ожидалось %v", test.input, again, actual)
+			}
+		}
+	}
+}
+
+func TestParseWindowList(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Window
+		ok       bool
+	}{
+		{"5,15,30", []Window{{5, 5}, {15, 15}, {30, 30}}, true},
+		{"5:15,30", []Window{{5, 15}, {30, 30}}, true},
+		{"5,5,5:5,15", []Window{{5, 5}, {15, 15}}, true},
+		{"5,,15", nil, false},
+		{"5,x", nil, false},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseWindowList(test.input)
+		if (err == nil) != test.ok || !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("ParseWindowList(%q) = %v, %v, ожидалось %v", test.input, actual, err, test.expected)
+		}
+	}
+}
+
+func TestParseWindows(t *testing.T) {
+	windows, err := ParseWindows(map[string]string{"title": "3", "body": "5:15"})
+	if err != nil || !reflect.DeepEqual(windows, map[string]Window{"title": {3, 3}, "body": {5, 15}}) {
+		t.Errorf("ParseWindows() = %v, %v", windows, err)
+	}
+
+	if _, err := ParseWindows(map[string]string{"title": "x"}); err == nil {
+		t.Errorf("ParseWindows() = nil, ожидалась ошибка")
+	}
+}
+
+func TestGetWindows(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{
+		Windows:         []Window{{5, 5}, {15, 15}},
+		FieldWindows:    map[string]Window{"title": {2, 3}},
+		LanguageWindows: map[string]Window{"ru": {10, 10}},
+	}
+
+	tests := []struct {
+		field    string
+		language string
+		expected []Window
+	}{
+		{"title", "ru", []Window{{2, 3}}},
+		{"title", "en", []Window{{2, 3}}},
+		{"body", "ru", []Window{{10, 10}}},
+		{"body", "en", []Window{{5, 5}, {15, 15}}},
+	}
+
+	for _, test := range tests {
+		if actual := getWindows(test.field, test.language); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("getWindows(%q, %q) = %v, ожидалось %v", test.field, test.language, actual, test.expected)
+		}
+	}
+}
+
+func TestWindowIndexName(t *testing.T) {
+	defer func(previous Config) { config = previous }(config)
+	config = Config{ProximityIndexPrefix: "p_"}
+
+	symmetric, asymmetric := Window{5, 5}.IndexName("en"), Window{5, 15}.IndexName("en")
+	if symmetric == asymmetric || asymmetric == (Window{15, 5}).IndexName("en") {
+		t.Errorf("Window.IndexName() совпадает для разных размерностей: %q, %q", symmetric, asymmetric)
+	}
+}
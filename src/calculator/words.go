@@ -0,0 +1,58 @@
+package calculator
+
+import (
+	"elastic-proximity-calculation/src/numbers"
+	"elastic-proximity-calculation/src/tokenizer"
+	"strconv"
+	"strings"
+)
+
+// maxNumberWords Максимальное количество слов в одном числительном
+const maxNumberWords = 12
+
+// mergeSpelledNumbers Функция объединяет числительные, записанные словами ("twenty-five", "сто двадцать"), в один числовой токен
+func mergeSpelledNumbers(text string, tokens []token, language string) []token {
+	merged := make([]token, 0, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Type != tokenizer.TypeWord || !numbers.CanStartWords(tokens[i].Text, language) {
+			merged = append(merged, tokens[i])
+			continue
+		}
+
+		// Слова числительного разделяются пробелами или дефисом
+		words := []string{tokens[i].Text}
+		for j := i + 1; j < len(tokens) && len(words) < maxNumberWords; j++ {
+			gap := strings.TrimSpace(text[tokens[j-1].End:tokens[j].Start])
+			if tokens[j].Type != tokenizer.TypeWord || (gap != "" && gap != "-") {
+				break
+			}
+			words = append(words, tokens[j].Text)
+		}
+
+		num, consumed := numbers.ParseWords(words, language)
+		if consumed == 0 {
+			merged = append(merged, tokens[i])
+			continue
+		}
+
+		first, last := tokens[i], tokens[i+consumed-1]
+		merged = append(merged, token{
+			Token: tokenizer.Token{
+				Type:       tokenizer.TypeNumber,
+				Text:       text[first.Start:last.End],
+				Normalized: strconv.FormatFloat(num, 'f', -1, 64),
+				Start:      first.Start,
+				End:        last.End,
+				CharStart:  first.CharStart,
+				CharEnd:    last.CharEnd,
+			},
+			isNumber:   true,
+			num:        num,
+			spelledOut: true,
+		})
+		i += consumed - 1
+	}
+
+	return merged
+}
@@ -0,0 +1,57 @@
+package calculator
+
+import "testing"
+
+func TestMergeSpelledNumbers(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		spelled  string
+		num      float64
+	}{
+		{"twenty-five layers", "en", "twenty-five", 25},
+		{"about one hundred and twenty samples", "en", "one hundred and twenty", 120},
+		{"сто двадцать образцов", "ru", "сто двадцать", 120},
+		{"three, four", "en", "three", 3},
+		{"a hundred samples", "en", "a hundred", 100},
+	}
+
+	for _, test := range tests {
+		var found *token
+		for _, current := range tokenizeText(test.language, test.text) {
+			if current.spelledOut {
+				found = &current
+				break
+			}
+		}
+
+		if found == nil {
+			t.Errorf("mergeSpelledNumbers(%q): числительное не найдено", test.text)
+			continue
+		}
+
+		if found.Text != test.spelled || found.num != test.num || !found.isNumber {
+			t.Errorf("mergeSpelledNumbers(%q) = %q (%v), ожидалось %q (%v)", test.text, found.Text, found.num, test.spelled, test.num)
+		}
+	}
+}
+
+func TestMergeSpelledNumbersVague(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+	}{
+		{"hundreds of layers", "en"},
+		{"thousands of cycles", "en"},
+		{"a layer of oxide", "en"},
+		{"тысячи деталей", "ru"},
+	}
+
+	for _, test := range tests {
+		for _, current := range tokenizeText(test.language, test.text) {
+			if current.isNumber {
+				t.Errorf("mergeSpelledNumbers(%q): найдено число %q", test.text, current.Text)
+			}
+		}
+	}
+}
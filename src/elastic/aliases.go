@@ -0,0 +1,153 @@
+package elastic
+
+import (
+	"bytes"
+	"elastic-proximity-calculation/src/helpers"
+	"encoding/json"
+	"errors"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetProximityGenerationIndexName Функция возвращает имя физического индекса поколения окрестностей: <псевдоним>_<время запуска>.
+// Имя индекса окрестностей (см. GetProximityWindowIndexName) при этом используется как псевдоним
+func GetProximityGenerationIndexName(alias string, start time.Time) string {
+	return alias + "_" + start.UTC().Format("20060102150405")
+}
+
+// IsProximityGenerationIndexName Функция проверяет, что имя индекса - имя физического индекса поколения окрестностей
+// (см. GetProximityGenerationIndexName): оканчивается на _<время запуска>
+func IsProximityGenerationIndexName(index string) bool {
+	separator := strings.LastIndex(index, "_")
+	if separator < 0 || len(index)-separator-1 != len("20060102150405") {
+		return false
+	}
+
+	_, err := time.Parse("20060102150405", index[separator+1:])
+
+	return err == nil
+}
+
+// GetAliases Функция возвращает псевдонимы, подходящие под шаблон имени, и индексы, на которые они указывают
+func GetAliases(client *elasticsearch.Client, pattern string) (map[string][]string, error) {
+	res, err := client.Indices.GetAlias(client.Indices.GetAlias.WithName(pattern))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	aliases := map[string][]string{}
+	if res.StatusCode == http.StatusNotFound {
+		return aliases, nil
+	}
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return nil, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	gjson.Parse(j).ForEach(func(index, value gjson.Result) bool {
+		value.Get("aliases").ForEach(func(alias, _ gjson.Result) bool {
+			aliases[alias.String()] = append(aliases[alias.String()], index.String())
+			return true
+		})
+		return true
+	})
+
+	return aliases, nil
+}
+
+// GetIndices Функция возвращает имена индексов, подходящих под шаблон имени (псевдонимы не раскрываются)
+func GetIndices(client *elasticsearch.Client, pattern string) ([]string, error) {
+	res, err := client.Cat.Indices(
+		client.Cat.Indices.WithIndex(pattern),
+		client.Cat.Indices.WithH("index"),
+		client.Cat.Indices.WithFormat("json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return nil, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	var indices []string
+	for _, index := range gjson.Get(j, "#.index").Array() {
+		indices = append(indices, index.String())
+	}
+
+	return indices, nil
+}
+
+// CountDocuments Функция обновляет индекс (refresh) и возвращает количество документов в нем
+func CountDocuments(client *elasticsearch.Client, index string) (int64, error) {
+	refresh, err := client.Indices.Refresh(client.Indices.Refresh.WithIndex(index))
+	if err != nil {
+		return 0, err
+	}
+	defer refresh.Body.Close()
+
+	if refresh.IsError() {
+		return 0, errors.New("ошибка в ответе от Elasticsearch: " + helpers.ReaderToString(refresh.Body))
+	}
+
+	res, err := client.Count(client.Count.WithIndex(index))
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return 0, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	return gjson.Get(j, "count").Int(), nil
+}
+
+// UpdateAliases Функция выполняет действия с псевдонимами (add, remove, remove_index) одним атомарным запросом
+func UpdateAliases(client *elasticsearch.Client, actions []interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"actions": actions,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Indices.UpdateAliases(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New("ошибка в ответе от Elasticsearch: " + helpers.ReaderToString(res.Body))
+	}
+
+	return nil
+}
+
+// DeleteIndices Функция удаляет индексы
+func DeleteIndices(client *elasticsearch.Client, indices []string) error {
+	res, err := client.Indices.Delete(indices)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New("ошибка в ответе от Elasticsearch: " + helpers.ReaderToString(res.Body))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package elastic
+
+import (
+	"encoding/json"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestClient Функция возвращает клиент, запросы которого обрабатывает handler вместо Elasticsearch.
+// Запрос информации о кластере, которым клиент проверяет продукт, обрабатывается здесь же
+func newTestClient(t *testing.T, handler http.HandlerFunc) *elasticsearch.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/" {
+			w.Write([]byte(`{"version": {"number": "7.17.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`))
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("elasticsearch.NewClient() = %v", err)
+	}
+
+	return client
+}
+
+func TestGetProximityGenerationIndexName(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	index := GetProximityGenerationIndexName("doc_proximity_words_5", start)
+	if index != "doc_proximity_words_5_20260102030405" {
+		t.Errorf("GetProximityGenerationIndexName() = %q", index)
+	}
+
+	if !IsProximityGenerationIndexName(index) {
+		t.Errorf("IsProximityGenerationIndexName(%q) = false, ожидалось true", index)
+	}
+}
+
+func TestIsProximityGenerationIndexName(t *testing.T) {
+	tests := []struct {
+		index    string
+		expected bool
+	}{
+		{"doc_proximity_words_5_20260102030405", true},
+		{"doc_proximity_words_5", false},
+		{"doc_proximity_words_5_20261399000000", false},
+		{"doc_proximity_words_5_2026010203040", false},
+		{"20260102030405", false},
+	}
+
+	for _, test := range tests {
+		if actual := IsProximityGenerationIndexName(test.index); actual != test.expected {
+			t.Errorf("IsProximityGenerationIndexName(%q) = %v, ожидалось %v", test.index, actual, test.expected)
+		}
+	}
+}
+
+func TestGetAliases(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"doc_proximity_words_5_20250101000000": {"aliases": {"doc_proximity_words_5": {}}},
+			"doc_proximity_chars_9_20250101000000": {"aliases": {"doc_proximity_chars_9": {}}}
+		}`))
+	})
+
+	aliases, err := GetAliases(client, "doc*_proximity_*")
+	if err != nil {
+		t.Fatalf("GetAliases() = %v", err)
+	}
+
+	expected := map[string][]string{
+		"doc_proximity_words_5": {"doc_proximity_words_5_20250101000000"},
+		"doc_proximity_chars_9": {"doc_proximity_chars_9_20250101000000"},
+	}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Errorf("GetAliases() = %v, ожидалось %v", aliases, expected)
+	}
+}
+
+func TestGetAliasesNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{}`))
+	})
+
+	aliases, err := GetAliases(client, "doc*_proximity_*")
+	if err != nil || len(aliases) != 0 {
+		t.Errorf("GetAliases() = %v, %v, ожидалось пустое множество", aliases, err)
+	}
+}
+
+func TestUpdateAliases(t *testing.T) {
+	var body []byte
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"acknowledged": true}`))
+	})
+
+	actions := []interface{}{
+		map[string]interface{}{"add": map[string]interface{}{"index": "a_20260101000000", "alias": "a"}},
+	}
+	if err := UpdateAliases(client, actions); err != nil {
+		t.Fatalf("UpdateAliases() = %v", err)
+	}
+
+	if !json.Valid(body) || gjson.GetBytes(body, "actions.0.add.alias").String() != "a" {
+		t.Errorf("UpdateAliases() тело запроса = %s", body)
+	}
+}
+
+func TestUpdateAliasesError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "index_not_found_exception"}`))
+	})
+
+	if err := UpdateAliases(client, nil); err == nil {
+		t.Errorf("UpdateAliases() = nil, ожидалась ошибка")
+	}
+}
@@ -5,15 +5,15 @@ import (
 	"elastic-proximity-calculation/src/logger"
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/elastic/go-elasticsearch/v7/esutil"
-	"strconv"
 	"time"
 )
 
 var bulkIndexers map[string]esutil.BulkIndexer = map[string]esutil.BulkIndexer{}
 
-// GetBulkIndexer Функция возвращает esutil.BulkIndexer настроенный на массового индексирования в конкретный языковой индекс
-func GetBulkIndexer(client *elasticsearch.Client, proximityIndexPrefix string, language string, proximityAmbit int) esutil.BulkIndexer {
-	key := proximityIndexPrefix + language + "_proximity_" + strconv.Itoa(proximityAmbit)
+// GetBulkIndexer Функция возвращает esutil.BulkIndexer настроенный на массового индексирования в конкретный индекс окрестностей
+// (см. GetProximityWindowIndexName)
+func GetBulkIndexer(client *elasticsearch.Client, index string) esutil.BulkIndexer {
+	key := index
 
 	if _, ok := bulkIndexers[key]; !ok {
 		tmpBulkIndexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
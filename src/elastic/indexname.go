@@ -0,0 +1,21 @@
+package elastic
+
+import "strconv"
+
+// GetProximityWindowIndexName Функция возвращает имя индекса окрестностей для несимметричной окрестности: <префикс><язык>_proximity_<слева>_<справа>.
+// Способ подсчета расстояния, если он указан, добавляется перед размерностью: <префикс><язык>_proximity_chars_100.
+// Для симметричной окрестности без способа подсчета размерность указывается один раз: <префикс><язык>_proximity_<размерность>.
+// Вместо языка допускается передавать "*" для поиска сразу по всем языковым индексам
+func GetProximityWindowIndexName(proximityIndexPrefix string, language string, strategy string, left int, right int) string {
+	name := proximityIndexPrefix + language + "_proximity_"
+	if strategy != "" {
+		name += strategy + "_"
+	}
+
+	name += strconv.Itoa(left)
+	if left != right {
+		name += "_" + strconv.Itoa(right)
+	}
+
+	return name
+}
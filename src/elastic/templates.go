@@ -0,0 +1,115 @@
+package elastic
+
+import (
+	"bytes"
+	"elastic-proximity-calculation/src/helpers"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"net/http"
+)
+
+// IndexTemplate Составной шаблон индекса (_index_template). Из нескольких шаблонов, подходящих под имя индекса,
+// Elasticsearch применяет только шаблон с наибольшим приоритетом, поэтому каждый шаблон содержит полные настройки и маппинг
+type IndexTemplate struct {
+	Name     string
+	Patterns []string
+	Priority int
+	Version  int
+	Settings map[string]interface{}
+	Mappings map[string]interface{}
+	Meta     map[string]interface{}
+}
+
+// PutIndexTemplate Функция устанавливает или обновляет шаблон индекса.
+// Шаблон более новой версии, установленный другим экземпляром калькулятора, не заменяется - возвращается ошибка
+func PutIndexTemplate(client *elasticsearch.Client, template IndexTemplate) error {
+	installed, err := getIndexTemplate(client, template.Name)
+	if err != nil {
+		return err
+	}
+
+	if version := int(installed.Get("version").Int()); version > template.Version {
+		return fmt.Errorf("установлен шаблон [%s] более новой версии %d (текущая версия %d)", template.Name, version, template.Version)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"index_patterns": template.Patterns,
+		"priority":       template.Priority,
+		"version":        template.Version,
+		"_meta":          template.Meta,
+		"template": map[string]interface{}{
+			"settings": template.Settings,
+			"mappings": template.Mappings,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Indices.PutIndexTemplate(template.Name, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New("ошибка в ответе от Elasticsearch: " + helpers.ReaderToString(res.Body))
+	}
+
+	return nil
+}
+
+// GetIndexTemplateMeta Функция возвращает значение из _meta установленного шаблона индекса.
+// Если шаблон не установлен или значения нет, возвращается пустая строка
+func GetIndexTemplateMeta(client *elasticsearch.Client, name string, key string) (string, error) {
+	installed, err := getIndexTemplate(client, name)
+	if err != nil {
+		return "", err
+	}
+
+	return installed.Get("_meta." + key).String(), nil
+}
+
+// GetIndexMappingMeta Функция возвращает _meta маппинга индекса (или индекса, на который указывает псевдоним)
+// и признак существования индекса
+func GetIndexMappingMeta(client *elasticsearch.Client, index string) (gjson.Result, bool, error) {
+	res, err := client.Indices.GetMapping(client.Indices.GetMapping.WithIndex(index))
+	if err != nil {
+		return gjson.Result{}, false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return gjson.Result{}, false, nil
+	}
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return gjson.Result{}, false, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	return gjson.Get(j, "*.mappings._meta"), true, nil
+}
+
+// getIndexTemplate Функция возвращает установленный шаблон индекса. Если шаблон не установлен, результат пустой
+func getIndexTemplate(client *elasticsearch.Client, name string) (gjson.Result, error) {
+	res, err := client.Indices.GetIndexTemplate(client.Indices.GetIndexTemplate.WithName(name))
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return gjson.Result{}, nil
+	}
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return gjson.Result{}, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	return gjson.Get(j, "index_templates.0.index_template"), nil
+}
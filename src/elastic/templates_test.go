@@ -0,0 +1,78 @@
+package elastic
+
+import (
+	"github.com/tidwall/gjson"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestPutIndexTemplate(t *testing.T) {
+	var body []byte
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"acknowledged": true}`))
+	})
+
+	template := IndexTemplate{
+		Name:     "p_proximity_default",
+		Patterns: []string{"p_*_proximity_*"},
+		Priority: 113,
+		Version:  2,
+		Mappings: map[string]interface{}{"_meta": map[string]interface{}{"layout": "flat"}},
+		Meta:     map[string]interface{}{"layout": "flat"},
+	}
+	if err := PutIndexTemplate(client, template); err != nil {
+		t.Fatalf("PutIndexTemplate() = %v", err)
+	}
+
+	j := gjson.ParseBytes(body)
+	if j.Get("priority").Int() != 113 || j.Get("version").Int() != 2 || j.Get("template.mappings._meta.layout").String() != "flat" {
+		t.Errorf("PutIndexTemplate() тело запроса = %s", body)
+	}
+}
+
+func TestPutIndexTemplateNewerVersion(t *testing.T) {
+	put := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"index_templates": [{"name": "p_proximity_default", "index_template": {"version": 3}}]}`))
+			return
+		}
+
+		put = true
+		w.Write([]byte(`{"acknowledged": true}`))
+	})
+
+	if err := PutIndexTemplate(client, IndexTemplate{Name: "p_proximity_default", Version: 2}); err == nil || put {
+		t.Errorf("PutIndexTemplate() = %v, шаблон заменен: %v, ожидалась ошибка без замены", err, put)
+	}
+}
+
+func TestGetIndexMappingMeta(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing/_mapping" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		w.Write([]byte(`{"p_en_proximity_words_5_20260101000000": {"mappings": {"_meta": {"mapping_version": 2, "layout": "flat"}}}}`))
+	})
+
+	meta, exists, err := GetIndexMappingMeta(client, "p_en_proximity_words_5")
+	if err != nil || !exists || meta.Get("layout").String() != "flat" || meta.Get("mapping_version").Int() != 2 {
+		t.Errorf("GetIndexMappingMeta() = %v, %v, %v", meta, exists, err)
+	}
+
+	_, exists, err = GetIndexMappingMeta(client, "missing")
+	if err != nil || exists {
+		t.Errorf("GetIndexMappingMeta(missing) = %v, %v, ожидалось отсутствие индекса", exists, err)
+	}
+}
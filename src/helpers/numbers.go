@@ -5,16 +5,12 @@ import (
 	"strconv"
 )
 
-// StringToFloat64 Функция для конвертирования строки в float64
-func StringToFloat64(stringNumber string) float64 {
-	num, _ := strconv.ParseFloat(stringNumber, 64)
-	num = Round(num, 5)
-
-	return num
-}
-
 // Round функция для округления float64 до определенного знака после запятой
 func Round(x float64, prec int) float64 {
+	if x < 0 {
+		return -Round(-x, prec)
+	}
+
 	var rounder float64
 	pow := math.Pow(10, float64(prec))
 	interred := x * pow
@@ -28,12 +24,9 @@ func Round(x float64, prec int) float64 {
 	return rounder / pow
 }
 
-// IsNumber функция для проверки наличия ТОЛЬКО числа в строке.
-// Предполагает, что число не является math.NaN, (+-)math.Inf и -0
-func IsNumber(token string) bool {
-	if num, err := strconv.ParseFloat(token, 64); err == nil && !math.IsNaN(num) && !math.IsInf(num, 0) && !math.IsInf(num, -1) && !math.IsInf(num, 1) && num != -0 {
-		return true
-	}
+// RoundSignificant функция для округления float64 до определенного количества значащих цифр
+func RoundSignificant(x float64, digits int) float64 {
+	num, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'g', digits, 64), 64)
 
-	return false
+	return num
 }
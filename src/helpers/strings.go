@@ -2,8 +2,10 @@ package helpers
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"math/rand"
+	"strings"
 	"time"
 	"unsafe"
 )
@@ -15,6 +17,41 @@ func ReaderToString(r io.Reader) string {
 	return b.String()
 }
 
+// ParseMap Функция для разбора строки вида "key1=value1,key2=value2" в map.
+// Пустая строка дает пустой map
+func ParseMap(s string) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, errors.New("ожидалась пара ключ=значение, получено: " + pair)
+		}
+
+		result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return result, nil
+}
+
+// ParseList Функция для разбора строки вида "value1,value2" в список. Пустые значения пропускаются
+func ParseList(s string) []string {
+	var result []string
+
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
 func RandomString(n int) string {
 	var src = rand.NewSource(time.Now().UnixNano())
 	const (
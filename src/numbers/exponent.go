@@ -0,0 +1,115 @@
+package numbers
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	exponentNone = iota
+	// exponentScientific 1.5e-3
+	exponentScientific
+	// exponentPower 10^6, 10⁶ - степень самого числа
+	exponentPower
+	// exponentMultiplication 2×10⁻³, 1.2·10^5 - умножение на степень десяти
+	exponentMultiplication
+)
+
+const (
+	signs        = "-−+"
+	superscripts = "⁰¹²³⁴⁵⁶⁷⁸⁹"
+	multipliers  = "×x·⋅*"
+)
+
+// exponentPattern Фрагмент регулярного выражения для записи степени после мантиссы
+var exponentPattern = `(?:[eE][-−+]?[0-9]+|` + powerPattern + `|\s?[` + multipliers + `]\s?10(?:` + powerPattern + `))`
+
+// powerPattern Фрагмент регулярного выражения для степени: ^6, ^-3, ^(-3), ⁶, ⁻³
+var powerPattern = `\^\(?[-−+]?[0-9]+\)?|[⁻⁺]?[` + superscripts + `]+`
+
+// splitSign Функция отделяет знак числа
+func splitSign(token string) (string, bool) {
+	r, size := utf8.DecodeRuneInString(token)
+	if size > 0 && strings.ContainsRune(signs, r) {
+		return token[size:], r != '+'
+	}
+
+	return token, false
+}
+
+// splitExponent Функция отделяет от записи числа степень.
+// Возвращает мантиссу, вид степени и показатель степени
+func splitExponent(token string) (string, int, int, bool) {
+	for i, r := range token {
+		switch {
+		case r == 'e' || r == 'E':
+			exponent, ok := parseExponent(token[i+1:])
+			return token[:i], exponentScientific, exponent, ok
+		case r == '^' || strings.ContainsRune(superscripts, r) || r == '⁻' || r == '⁺':
+			exponent, ok := parseExponent(token[i:])
+			return token[:i], exponentPower, exponent, ok
+		case strings.ContainsRune(multipliers, r):
+			power := strings.TrimLeft(token[i+utf8.RuneLen(r):], " ")
+			if !strings.HasPrefix(power, "10") {
+				return token, exponentNone, 0, false
+			}
+
+			exponent, ok := parseExponent(power[2:])
+			return strings.TrimRight(token[:i], " "), exponentMultiplication, exponent, ok
+		}
+	}
+
+	return token, exponentNone, 0, true
+}
+
+// parseExponent Функция разбирает показатель степени в любой из записей: -3, ^-3, ^(-3), ⁻³
+func parseExponent(text string) (int, bool) {
+	text = strings.TrimPrefix(text, "^")
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "("), ")")
+
+	negative := false
+	if r, size := utf8.DecodeRuneInString(text); r == '⁻' || r == '⁺' {
+		negative = r == '⁻'
+		text = text[size:]
+	} else {
+		text, negative = splitSign(text)
+	}
+
+	if text == "" {
+		return 0, false
+	}
+
+	exponent := 0
+	for _, r := range text {
+		digit := strings.IndexRune(superscripts, r)
+		if digit >= 0 {
+			// Каждый надстрочный символ занимает 2-3 байта, поэтому считаем по рунам
+			digit = utf8.RuneCountInString(superscripts[:digit])
+		} else if isDigit(r) {
+			digit = int(r - '0')
+		} else {
+			return 0, false
+		}
+
+		exponent = exponent*10 + digit
+	}
+
+	if negative {
+		exponent = -exponent
+	}
+
+	return exponent, true
+}
+
+// applyExponent Функция применяет степень к мантиссе
+func applyExponent(mantissa float64, kind int, exponent int) float64 {
+	switch kind {
+	case exponentScientific, exponentMultiplication:
+		return mantissa * math.Pow(10, float64(exponent))
+	case exponentPower:
+		return math.Pow(mantissa, float64(exponent))
+	default:
+		return mantissa
+	}
+}
@@ -0,0 +1,34 @@
+package numbers
+
+import "testing"
+
+func TestParseExponent(t *testing.T) {
+	tests := []struct {
+		token string
+		num   float64
+		ok    bool
+	}{
+		{"-40", -40, true},
+		{"−40", -40, true},
+		{"+5", 5, true},
+		{"1.5e-3", 0.0015, true},
+		{"1.5E3", 1500, true},
+		{"10^6", 1000000, true},
+		{"10^(-3)", 0.001, true},
+		{"10⁶", 1000000, true},
+		{"10⁻³", 0.001, true},
+		{"2×10⁻³", 0.002, true},
+		{"1.2·10^5", 120000, true},
+		{"3 x 10^2", 300, true},
+		{"-2.5e-9", -2.5e-9, true},
+		{"2×5", 0, false},
+		{"1e", 0, false},
+	}
+
+	for _, test := range tests {
+		num, ok := Parse(test.token, "en")
+		if ok != test.ok || (ok && num != test.num) {
+			t.Errorf("Parse(%q) = %v, %v, ожидалось %v, %v", test.token, num, ok, test.num, test.ok)
+		}
+	}
+}
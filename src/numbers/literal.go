@@ -0,0 +1,105 @@
+package numbers
+
+import (
+	"elastic-proximity-calculation/src/helpers"
+	"math"
+	"strings"
+)
+
+// Literal Сведения о точности, с которой число записано в тексте
+type Literal struct {
+	// SignificantDigits Количество значащих цифр: 3 для 5.00 и 0.0500, 1 для 500
+	SignificantDigits int
+	// Resolution Цена последнего значащего разряда: 0.01 для 5.00, 100 для 500, 100 для 1.5e3.
+	// Нулевое значение означает, что точность записи неизвестна (10⁶, 1½)
+	Resolution float64
+}
+
+// Describe Функция определяет точность записи числа с учетом правил записи чисел языка
+func Describe(token string, language string) (Literal, bool) {
+	return GetLocale(language).Describe(token)
+}
+
+// Describe Функция определяет точность записи числа: количество значащих цифр и цену последнего значащего разряда.
+// Нули в конце целого числа без дробной части значащими не считаются - ни в количестве цифр, ни в цене разряда
+func (l Locale) Describe(token string) (Literal, bool) {
+	unsigned, _ := splitSign(normalizeDigits(token))
+
+	mantissa, exponentKind, exponent, ok := splitExponent(unsigned)
+	if !ok {
+		return Literal{}, false
+	}
+
+	if rest, fraction := splitFraction(mantissa); fraction > 0 {
+		return Literal{SignificantDigits: significantDigits(digitsOf(rest), "")}, true
+	}
+
+	runes := []rune(mantissa)
+	integerPart, fractionPart := runes, []rune(nil)
+	if index := l.decimalIndex(runes); index >= 0 {
+		integerPart, fractionPart = runes[:index], runes[index+1:]
+	}
+
+	integerDigits, fractionDigits := digitsOf(string(integerPart)), digitsOf(string(fractionPart))
+	if integerDigits == "" && fractionDigits == "" {
+		return Literal{}, false
+	}
+
+	literal := Literal{SignificantDigits: significantDigits(integerDigits, fractionDigits)}
+
+	power := trailingZeros(integerDigits, fractionDigits) - len(fractionDigits)
+
+	switch exponentKind {
+	case exponentNone:
+		literal.Resolution = math.Pow(10, float64(power))
+	case exponentScientific, exponentMultiplication:
+		literal.Resolution = helpers.RoundSignificant(math.Pow(10, float64(exponent+power)), 12)
+	}
+
+	return literal, true
+}
+
+// ImpliedInterval Функция возвращает интервал, который подразумевает запись числа: ± половина цены последнего разряда.
+// Для 5.00 это [4.995, 5.005], для 5 - [4.5, 5.5]
+func (l Literal) ImpliedInterval(num float64) (float64, float64, bool) {
+	if l.Resolution == 0 {
+		return 0, 0, false
+	}
+
+	half := l.Resolution / 2
+
+	return helpers.RoundSignificant(num-half, 12), helpers.RoundSignificant(num+half, 12), true
+}
+
+func digitsOf(s string) string {
+	var digits strings.Builder
+	for _, r := range s {
+		if isDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+
+	return digits.String()
+}
+
+// trailingZeros Функция возвращает количество незначащих нулей в конце целого числа без дробной части: 2 для 500, 0 для 500.0
+func trailingZeros(integerDigits string, fractionDigits string) int {
+	if fractionDigits != "" || strings.Trim(integerDigits, "0") == "" {
+		return 0
+	}
+
+	return len(integerDigits) - len(strings.TrimRight(integerDigits, "0"))
+}
+
+func significantDigits(integerDigits string, fractionDigits string) int {
+	digits := strings.TrimLeft(integerDigits+fractionDigits, "0")
+	if fractionDigits == "" {
+		digits = strings.TrimRight(digits, "0")
+	}
+
+	if digits == "" {
+		return 1
+	}
+
+	return len(digits)
+}
@@ -0,0 +1,53 @@
+package numbers
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		token      string
+		language   string
+		num        float64
+		digits     int
+		resolution float64
+		min, max   float64
+	}{
+		{"5", "en", 5, 1, 1, 4.5, 5.5},
+		{"5.00", "en", 5, 3, 0.01, 4.995, 5.005},
+		{"0.0500", "en", 0.05, 3, 0.0001, 0.04995, 0.05005},
+		{"500", "en", 500, 1, 100, 450, 550},
+		{"500.0", "en", 500, 4, 0.1, 499.95, 500.05},
+		{"5,00", "ru", 5, 3, 0.01, 4.995, 5.005},
+		{"1.5e3", "en", 1500, 2, 100, 1450, 1550},
+	}
+
+	for _, test := range tests {
+		literal, ok := Describe(test.token, test.language)
+		if !ok || literal.SignificantDigits != test.digits || literal.Resolution != test.resolution {
+			t.Errorf("Describe(%q, %q) = %+v, %v, ожидалось %d цифр и цена разряда %v", test.token, test.language, literal, ok, test.digits, test.resolution)
+			continue
+		}
+
+		min, max, ok := literal.ImpliedInterval(test.num)
+		if !ok || min != test.min || max != test.max {
+			t.Errorf("ImpliedInterval(%q) = [%v, %v], ожидалось [%v, %v]", test.token, min, max, test.min, test.max)
+		}
+	}
+}
+
+func TestDescribeUnknownResolution(t *testing.T) {
+	for _, token := range []string{"10⁶", "1½"} {
+		literal, ok := Describe(token, "en")
+		if !ok {
+			t.Errorf("Describe(%q) не распознал число", token)
+			continue
+		}
+
+		if _, _, ok := literal.ImpliedInterval(1); ok {
+			t.Errorf("Describe(%q) = %+v, ожидалась неизвестная точность", token, literal)
+		}
+	}
+
+	if _, ok := Describe("abc", "en"); ok {
+		t.Errorf("Describe(%q) распознал число", "abc")
+	}
+}
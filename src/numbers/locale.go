@@ -0,0 +1,97 @@
+package numbers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Locale Правила записи чисел для конкретного языка
+type Locale struct {
+	// Decimal Десятичный разделитель
+	Decimal rune
+	// Groups Допустимые разделители групп разрядов (тысяч)
+	Groups string
+}
+
+var (
+	// pointLocale Десятичная точка, группы разрядов через запятую (1,000.5)
+	pointLocale = Locale{Decimal: '.', Groups: ",\u00a0\u202f'’٬"}
+	// commaSpaceLocale Десятичная запятая, группы разрядов через пробел (1 000,5)
+	commaSpaceLocale = Locale{Decimal: ',', Groups: " \u00a0\u202f'’"}
+	// commaPointLocale Десятичная запятая, группы разрядов через точку (1.000,5)
+	commaPointLocale = Locale{Decimal: ',', Groups: ". \u00a0\u202f'’"}
+
+	locales = map[string]Locale{
+		"en": pointLocale,
+		"zh": pointLocale,
+		"ja": pointLocale,
+		"ko": pointLocale,
+		"ar": pointLocale,
+		"fa": pointLocale,
+		"ur": pointLocale,
+		"hi": pointLocale,
+		"ru": commaSpaceLocale,
+		"uk": commaSpaceLocale,
+		"be": commaSpaceLocale,
+		"kk": commaSpaceLocale,
+		"fr": commaSpaceLocale,
+		"pl": commaSpaceLocale,
+		"cs": commaSpaceLocale,
+		"sk": commaSpaceLocale,
+		"bg": commaSpaceLocale,
+		"sv": commaSpaceLocale,
+		"fi": commaSpaceLocale,
+		"no": commaSpaceLocale,
+		"nb": commaSpaceLocale,
+		"hu": commaSpaceLocale,
+		"lt": commaSpaceLocale,
+		"lv": commaSpaceLocale,
+		"et": commaSpaceLocale,
+		"de": commaPointLocale,
+		"nl": commaPointLocale,
+		"da": commaPointLocale,
+		"es": commaPointLocale,
+		"it": commaPointLocale,
+		"pt": commaPointLocale,
+		"ro": commaPointLocale,
+		"tr": commaPointLocale,
+		"el": commaPointLocale,
+		"id": commaPointLocale,
+		"hr": commaPointLocale,
+		"sl": commaPointLocale,
+		"sr": commaPointLocale,
+	}
+)
+
+// GetLocale Функция возвращает правила записи чисел для языка.
+// Для неизвестных языков используется десятичная точка
+func GetLocale(language string) Locale {
+	if locale, ok := locales[strings.ToLower(language)]; ok {
+		return locale
+	}
+
+	return pointLocale
+}
+
+// Pattern Функция возвращает фрагмент регулярного выражения, которому соответствует число в записи данного языка.
+// Число может иметь знак и степень: -40, 1.5e-3, 10^6, 2×10⁻³, быть записано цифрами любой системы письма (١٢٣, १२३, １２３),
+// дробным символом (½, 1¾) или надстрочными цифрами (²)
+func (l Locale) Pattern() string {
+	var groups strings.Builder
+	for _, group := range l.Groups {
+		groups.WriteString(regexp.QuoteMeta(string(group)))
+	}
+
+	digit := `\p{Nd}`
+	decimal := `[` + decimals + `]`
+	mantissa := digit + `{1,3}(?:[` + groups.String() + `]` + digit + `{3})+(?:` + decimal + digit + `+)?|` +
+		digit + `+(?:` + decimal + digit + `+)?[` + fractions + `]?|` +
+		decimal + digit + `+|` +
+		`[` + fractions + `]`
+
+	return `[` + signs + `]?(?:` + mantissa + `)` + exponentPattern + `?|[` + superscripts + `]+`
+}
+
+func (l Locale) isGroup(r rune) bool {
+	return strings.ContainsRune(l.Groups, r)
+}
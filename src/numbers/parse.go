@@ -0,0 +1,152 @@
+package numbers
+
+import (
+	"elastic-proximity-calculation/src/helpers"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Precision Количество знаков после запятой, до которого округляются разобранные числа
+var Precision = 5
+
+// Parse Функция для разбора числа с учетом правил записи чисел языка.
+// Возвращает false, если токен не является числом
+func Parse(token string, language string) (float64, bool) {
+	return GetLocale(language).Parse(token)
+}
+
+// Parse Функция для разбора числа с учетом знака, степени, десятичного разделителя и разделителей групп разрядов локали.
+// Точка, не образующая корректных групп разрядов, считается десятичным разделителем в любой локали
+func (l Locale) Parse(token string) (float64, bool) {
+	unsigned, negative := splitSign(normalizeDigits(token))
+
+	mantissa, exponentKind, exponent, ok := splitExponent(unsigned)
+	if !ok {
+		return 0, false
+	}
+
+	num, ok := l.parseMantissa(mantissa)
+	if !ok {
+		return 0, false
+	}
+
+	num = applyExponent(num, exponentKind, exponent)
+	if negative {
+		num = -num
+	}
+
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return 0, false
+	}
+
+	// Числа со степенью бывают очень маленькими (2×10⁻⁹), округление до знаков после запятой превратило бы их в 0
+	if exponentKind != exponentNone {
+		return helpers.RoundSignificant(num, 12), true
+	}
+
+	return helpers.Round(num, Precision), true
+}
+
+// parseMantissa Функция для разбора числа без знака и степени, в том числе с дробным символом (1½)
+func (l Locale) parseMantissa(token string) (float64, bool) {
+	token, fraction := splitFraction(token)
+	if fraction > 0 && token == "" {
+		return fraction, true
+	}
+
+	num, ok := l.parseDecimal(token)
+
+	return num + fraction, ok
+}
+
+// parseDecimal Функция для разбора десятичной записи числа с разделителями групп разрядов
+func (l Locale) parseDecimal(token string) (float64, bool) {
+	runes := []rune(token)
+	decimalIndex := l.decimalIndex(runes)
+
+	integerPart, fractionPart := runes, []rune(nil)
+	if decimalIndex >= 0 {
+		integerPart, fractionPart = runes[:decimalIndex], runes[decimalIndex+1:]
+	}
+
+	var normalized strings.Builder
+	var group rune
+	for _, r := range integerPart {
+		if isDigit(r) {
+			normalized.WriteRune(r)
+			continue
+		}
+
+		if !l.isGroup(r) || (group != 0 && r != group) {
+			return 0, false
+		}
+		group = r
+	}
+
+	if group != 0 && !isValidGrouping(integerPart, group) {
+		return 0, false
+	}
+
+	if decimalIndex >= 0 {
+		if len(fractionPart) == 0 {
+			return 0, false
+		}
+
+		normalized.WriteRune('.')
+		for _, r := range fractionPart {
+			if !isDigit(r) {
+				return 0, false
+			}
+			normalized.WriteRune(r)
+		}
+	}
+
+	num, err := strconv.ParseFloat(normalized.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return num, true
+}
+
+// decimalIndex Функция возвращает позицию десятичного разделителя в записи числа или -1
+func (l Locale) decimalIndex(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !isDigit(runes[i]) {
+			if runes[i] == l.Decimal || (runes[i] == '.' && !(l.isGroup('.') && isValidGrouping(runes, '.'))) {
+				return i
+			}
+			break
+		}
+	}
+
+	return -1
+}
+
+// isValidGrouping Функция проверяет, что разделитель группы делит число на корректные группы разрядов:
+// первая группа из 1-3 цифр, остальные ровно по 3 цифры
+func isValidGrouping(runes []rune, group rune) bool {
+	groups := strings.Split(string(runes), string(group))
+	if len(groups) < 2 || len(groups[0]) < 1 || len(groups[0]) > 3 {
+		return false
+	}
+
+	for i, part := range groups {
+		for _, r := range part {
+			if !isDigit(r) {
+				return false
+			}
+		}
+
+		if i > 0 && len(part) != 3 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
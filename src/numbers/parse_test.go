@@ -0,0 +1,36 @@
+package numbers
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		token    string
+		language string
+		num      float64
+		ok       bool
+	}{
+		{"1,000.5", "en", 1000.5, true},
+		{"1,5", "en", 0, false},
+		{"1,5", "ru", 1.5, true},
+		{"1\u00a0000,5", "ru", 1000.5, true},
+		{"1 000,5", "ru", 1000.5, true},
+		{"1 000", "fr", 1000, true},
+		{"3,14", "ru", 3.14, true},
+		{"1.000,5", "de", 1000.5, true},
+		{"1.5", "de", 1.5, true},
+		{"2.50", "ru", 2.5, true},
+		{"1,000,000", "en", 1000000, true},
+		{"12,34,567", "en", 0, false},
+		{"1'234'567", "en", 1234567, true},
+		{"0.123456789", "en", 0.12346, true},
+		{"abc", "en", 0, false},
+		{"", "en", 0, false},
+	}
+
+	for _, test := range tests {
+		num, ok := Parse(test.token, test.language)
+		if ok != test.ok || (ok && num != test.num) {
+			t.Errorf("Parse(%q, %q) = %v, %v, ожидалось %v, %v", test.token, test.language, num, ok, test.num, test.ok)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package numbers
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// fractions Дробные символы Unicode, в том числе в записи вида 1½
+	fractions = "½⅓⅔¼¾⅕⅖⅗⅘⅙⅚⅐⅛⅜⅝⅞⅑⅒"
+	// decimals Десятичные разделители: точка, запятая, арабский (٫) и полноширинный (．)
+	decimals = ".,٫．"
+)
+
+var fractionValues = map[rune]float64{
+	'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4,
+	'⅕': 1.0 / 5, '⅖': 2.0 / 5, '⅗': 3.0 / 5, '⅘': 4.0 / 5, '⅙': 1.0 / 6,
+	'⅚': 5.0 / 6, '⅐': 1.0 / 7, '⅛': 1.0 / 8, '⅜': 3.0 / 8, '⅝': 5.0 / 8,
+	'⅞': 7.0 / 8, '⅑': 1.0 / 9, '⅒': 1.0 / 10,
+}
+
+// normalizeDigits Функция приводит цифры любых систем письма (арабско-индийские, деванагари, полноширинные и т.д.)
+// к ASCII, а арабские и полноширинные разделители - к точке и запятой.
+// Строка, целиком состоящая из надстрочных цифр, также приводится к ASCII
+func normalizeDigits(token string) string {
+	onlySuperscripts := token != ""
+	for _, r := range token {
+		if !strings.ContainsRune(superscripts, r) {
+			onlySuperscripts = false
+			break
+		}
+	}
+
+	var normalized strings.Builder
+	for _, r := range token {
+		switch {
+		case r >= '0' && r <= '9':
+			normalized.WriteRune(r)
+		case unicode.Is(unicode.Nd, r):
+			normalized.WriteRune('0' + digitValue(r))
+		case onlySuperscripts:
+			normalized.WriteRune('0' + rune(utf8.RuneCountInString(superscripts[:strings.IndexRune(superscripts, r)])))
+		case r == '٫' || r == '．':
+			normalized.WriteRune('.')
+		case r == '٬':
+			normalized.WriteRune(',')
+		default:
+			normalized.WriteRune(r)
+		}
+	}
+
+	return normalized.String()
+}
+
+// digitValue Функция возвращает значение десятичной цифры Unicode.
+// В таблице unicode.Nd каждый диапазон начинается с нуля и содержит целое число десятков цифр
+func digitValue(r rune) rune {
+	for _, digits := range unicode.Nd.R16 {
+		if r >= rune(digits.Lo) && r <= rune(digits.Hi) {
+			return (r - rune(digits.Lo)) % 10
+		}
+	}
+
+	for _, digits := range unicode.Nd.R32 {
+		if r >= rune(digits.Lo) && r <= rune(digits.Hi) {
+			return (r - rune(digits.Lo)) % 10
+		}
+	}
+
+	return 0
+}
+
+// splitFraction Функция отделяет от записи числа дробный символ: 1½ -> 1 и 0.5
+func splitFraction(token string) (string, float64) {
+	for r, value := range fractionValues {
+		if strings.HasSuffix(token, string(r)) {
+			return strings.TrimSuffix(token, string(r)), value
+		}
+	}
+
+	return token, 0
+}
@@ -0,0 +1,32 @@
+package numbers
+
+import "testing"
+
+func TestParseUnicode(t *testing.T) {
+	tests := []struct {
+		token    string
+		language string
+		num      float64
+		ok       bool
+	}{
+		{"١٢٣", "ar", 123, true},
+		{"١٢٫٥", "ar", 12.5, true},
+		{"١٬٠٠٠", "ar", 1000, true},
+		{"१२३", "hi", 123, true},
+		{"１２３", "ja", 123, true},
+		{"３．５", "ja", 3.5, true},
+		{"๔๒", "th", 42, true},
+		{"½", "en", 0.5, true},
+		{"1½", "en", 1.5, true},
+		{"2¾", "en", 2.75, true},
+		{"²", "en", 2, true},
+		{"¹²", "en", 12, true},
+	}
+
+	for _, test := range tests {
+		num, ok := Parse(test.token, test.language)
+		if ok != test.ok || (ok && num != test.num) {
+			t.Errorf("Parse(%q, %q) = %v, %v, ожидалось %v, %v", test.token, test.language, num, ok, test.num, test.ok)
+		}
+	}
+}
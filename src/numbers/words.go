@@ -0,0 +1,197 @@
+package numbers
+
+import "strings"
+
+const (
+	wordNone = iota
+	wordZero
+	wordUnit
+	wordTeen
+	wordTen
+	// wordHundred Множитель "hundred": two hundred = 2 * 100
+	wordHundred
+	// wordHundreds Сотни одним словом: двести = 200
+	wordHundreds
+	wordScale
+	// wordArticle Артикль перед множителем: a hundred = 100. Без множителя числительным не является
+	wordArticle
+)
+
+type numberWord struct {
+	value float64
+	kind  int
+}
+
+// wordTransitions Допустимые последовательности видов числительных: "twenty five", но не "five twenty".
+// Множители "hundred", "тысяча" стоят только после числа ("two hundred", "a hundred", "две тысячи"):
+// без числа это неопределенное количество: "hundreds of layers", "тысячи деталей"
+var wordTransitions = map[int][]int{
+	wordNone:     {wordZero, wordUnit, wordTeen, wordTen, wordHundreds, wordArticle},
+	wordArticle:  {wordHundred, wordScale},
+	wordUnit:     {wordHundred, wordScale},
+	wordTeen:     {wordHundred, wordScale},
+	wordTen:      {wordUnit, wordScale},
+	wordHundred:  {wordUnit, wordTeen, wordTen, wordScale},
+	wordHundreds: {wordUnit, wordTeen, wordTen, wordScale},
+	wordScale:    {wordUnit, wordTeen, wordTen, wordHundreds},
+}
+
+// connectors Слова, которые могут стоять внутри числительного: "one hundred and five"
+var connectors = map[string]map[string]bool{
+	"en": {"and": true},
+}
+
+var numberWords = map[string]map[string]numberWord{
+	"en": buildNumberWords(map[int][]string{
+		0: {"zero"}, 1: {"one"}, 2: {"two"}, 3: {"three"}, 4: {"four"}, 5: {"five"},
+		6: {"six"}, 7: {"seven"}, 8: {"eight"}, 9: {"nine"}, 10: {"ten"},
+		11: {"eleven"}, 12: {"twelve"}, 13: {"thirteen"}, 14: {"fourteen"}, 15: {"fifteen"},
+		16: {"sixteen"}, 17: {"seventeen"}, 18: {"eighteen"}, 19: {"nineteen"},
+		20: {"twenty"}, 30: {"thirty"}, 40: {"forty"}, 50: {"fifty"},
+		60: {"sixty"}, 70: {"seventy"}, 80: {"eighty"}, 90: {"ninety"},
+		1e3: {"thousand"}, 1e6: {"million"}, 1e9: {"billion"},
+	}, map[string]numberWord{
+		"hundred": {100, wordHundred},
+		"a":       {1, wordArticle},
+	}),
+	"ru": buildNumberWords(map[int][]string{
+		0:  {"ноль", "нуль", "нуля"},
+		1:  {"один", "одна", "одно", "одного", "одной", "одному", "одним", "одном", "одну"},
+		2:  {"два", "две", "двух", "двум", "двумя"},
+		3:  {"три", "трех", "трем", "тремя"},
+		4:  {"четыре", "четырех", "четырем", "четырьмя"},
+		5:  {"пять", "пяти", "пятью"},
+		6:  {"шесть", "шести", "шестью"},
+		7:  {"семь", "семи", "семью"},
+		8:  {"восемь", "восьми", "восемью"},
+		9:  {"девять", "девяти", "девятью"},
+		10: {"десять", "десяти", "десятью"},
+		11: {"одиннадцать", "одиннадцати"}, 12: {"двенадцать", "двенадцати"},
+		13: {"тринадцать", "тринадцати"}, 14: {"четырнадцать", "четырнадцати"},
+		15: {"пятнадцать", "пятнадцати"}, 16: {"шестнадцать", "шестнадцати"},
+		17: {"семнадцать", "семнадцати"}, 18: {"восемнадцать", "восемнадцати"},
+		19: {"девятнадцать", "девятнадцати"},
+		20: {"двадцать", "двадцати"}, 30: {"тридцать", "тридцати"}, 40: {"сорок", "сорока"},
+		50: {"пятьдесят", "пятидесяти"}, 60: {"шестьдесят", "шестидесяти"},
+		70: {"семьдесят", "семидесяти"}, 80: {"восемьдесят", "восьмидесяти"},
+		90:  {"девяносто", "девяноста"},
+		100: {"сто", "ста"}, 200: {"двести", "двухсот"}, 300: {"триста", "трехсот"},
+		400: {"четыреста", "четырехсот"}, 500: {"пятьсот", "пятисот"}, 600: {"шестьсот", "шестисот"},
+		700: {"семьсот", "семисот"}, 800: {"восемьсот", "восьмисот"}, 900: {"девятьсот", "девятисот"},
+		1e3: {"тысяча", "тысячи", "тысяч", "тысячу", "тысячей"},
+		1e6: {"миллион", "миллиона", "миллионов"},
+		1e9: {"миллиард", "миллиарда", "миллиардов"},
+	}, nil),
+}
+
+// buildNumberWords Функция строит словарь числительных, определяя вид слова по его значению
+func buildNumberWords(values map[int][]string, extra map[string]numberWord) map[string]numberWord {
+	words := map[string]numberWord{}
+
+	for value, forms := range values {
+		kind := wordUnit
+		switch {
+		case value == 0:
+			kind = wordZero
+		case value >= 1000:
+			kind = wordScale
+		case value >= 100:
+			kind = wordHundreds
+		case value >= 20:
+			kind = wordTen
+		case value >= 10:
+			kind = wordTeen
+		}
+
+		for _, form := range forms {
+			words[form] = numberWord{float64(value), kind}
+		}
+	}
+
+	for form, word := range extra {
+		words[form] = word
+	}
+
+	return words
+}
+
+// IsNumberWord Функция проверяет, является ли слово числительным языка. Артикль "a" числительным не считается
+func IsNumberWord(word string, language string) bool {
+	number, ok := numberWords[strings.ToLower(language)][normalizeWord(word)]
+
+	return ok && number.kind != wordArticle
+}
+
+// CanStartWords Функция проверяет, что со слова может начинаться числительное: это числительное или артикль ("a hundred")
+func CanStartWords(word string, language string) bool {
+	_, ok := numberWords[strings.ToLower(language)][normalizeWord(word)]
+
+	return ok
+}
+
+// ParseWords Функция разбирает числительное, записанное словами, начиная с первого слова: "twenty-five", "сто двадцать".
+// Возвращает значение и количество слов, образующих числительное (0, если первое слово не числительное)
+func ParseWords(words []string, language string) (float64, int) {
+	language = strings.ToLower(language)
+	dictionary := numberWords[language]
+
+	var total, current, lastScale float64
+	previous, consumed := wordNone, 0
+
+	for i := 0; i < len(words); i++ {
+		word := normalizeWord(words[i])
+
+		if connectors[language][word] && (previous == wordHundred || previous == wordScale) {
+			continue
+		}
+
+		next, ok := dictionary[word]
+		if !ok || !canFollow(previous, next.kind) {
+			break
+		}
+
+		switch next.kind {
+		case wordHundred:
+			if current == 0 {
+				current = 1
+			}
+			current *= next.value
+		case wordScale:
+			if lastScale != 0 && next.value >= lastScale {
+				return total + current, consumed
+			}
+			if current == 0 {
+				current = 1
+			}
+			total += current * next.value
+			current, lastScale = 0, next.value
+		default:
+			current += next.value
+		}
+
+		previous, consumed = next.kind, i+1
+		if next.kind == wordZero {
+			break
+		}
+	}
+
+	if previous == wordArticle {
+		return 0, 0
+	}
+
+	return total + current, consumed
+}
+
+func canFollow(previous int, next int) bool {
+	for _, allowed := range wordTransitions[previous] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeWord(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
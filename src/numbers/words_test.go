@@ -0,0 +1,65 @@
+package numbers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWords(t *testing.T) {
+	tests := []struct {
+		words    string
+		language string
+		num      float64
+		consumed int
+	}{
+		{"three layers", "en", 3, 1},
+		{"twenty five", "en", 25, 2},
+		{"one hundred and twenty", "en", 120, 4},
+		{"two thousand three hundred", "en", 2300, 4},
+		{"five million", "en", 5000000, 2},
+		{"zero", "en", 0, 1},
+		{"Twelve", "en", 12, 1},
+		{"сто двадцать", "ru", 120, 2},
+		{"две тысячи пятьсот", "ru", 2500, 3},
+		{"три слоя", "ru", 3, 1},
+		{"layers three", "en", 0, 0},
+		{"five five", "en", 5, 1},
+		{"a hundred layers", "en", 100, 2},
+		{"a thousand", "en", 1000, 2},
+		{"a layer", "en", 0, 0},
+		{"hundreds of layers", "en", 0, 0},
+		{"hundred layers", "en", 0, 0},
+		{"thousands", "en", 0, 0},
+		{"тысячи деталей", "ru", 0, 0},
+		{"одна тысяча", "ru", 1000, 2},
+	}
+
+	for _, test := range tests {
+		num, consumed := ParseWords(strings.Fields(test.words), test.language)
+		if num != test.num || consumed != test.consumed {
+			t.Errorf("ParseWords(%q, %q) = %v, %d, ожидалось %v, %d", test.words, test.language, num, consumed, test.num, test.consumed)
+		}
+	}
+}
+
+func TestIsNumberWord(t *testing.T) {
+	tests := []struct {
+		word     string
+		language string
+		ok       bool
+	}{
+		{"seven", "en", true},
+		{"Seven", "en", true},
+		{"семь", "ru", true},
+		{"семь", "en", false},
+		{"layer", "en", false},
+		{"millions", "en", false},
+		{"a", "en", false},
+	}
+
+	for _, test := range tests {
+		if ok := IsNumberWord(test.word, test.language); ok != test.ok {
+			t.Errorf("IsNumberWord(%q, %q) = %v, ожидалось %v", test.word, test.language, ok, test.ok)
+		}
+	}
+}
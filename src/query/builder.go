@@ -0,0 +1,420 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"elastic-proximity-calculation/src/units"
+	"strconv"
+	"strings"
+)
+
+// Layout Формат записи соседей в индексе окрестностей (structs.LayoutFlat, structs.LayoutNested или structs.LayoutPositions)
+var Layout = structs.LayoutFlat
+
+// Strategy Способ подсчета расстояния в индексе окрестностей (см. structs.WindowStrategies).
+// При подсчете в символах расстояние в запросе задается в символах и сравнивается с полями db_N/da_N (neighbours.distance)
+var Strategy = structs.StrategyTokens
+
+// Range Числовой диапазон. Отсутствующая граница (nil) означает неограниченный диапазон с этой стороны.
+// Если указана единица измерения, границы переводятся в СИ и сравниваются с num_si (num_min_si/num_max_si)
+type Range struct {
+	Min          *float64
+	Max          *float64
+	MinExclusive bool
+	MaxExclusive bool
+	Unit         string
+}
+
+// Build Функция строит запрос Elasticsearch по плоской структуре окрестности (num, tb_N/ta_N).
+// Если в запросе указано выражение на языке запросов, то строится запрос по нему,
+// иначе слово ищется в полях tb_1..tb_N (слева от числа) и/или ta_1..ta_N (справа от числа), где N - требуемое расстояние.
+// При формате structs.LayoutNested слово ищется в nested-массиве neighbours с ограничением на смещение,
+// при формате structs.LayoutPositions - запросом span_near к полю context.
+// Если в выражении нет якорей, ищутся только окрестности чисел: окрестности терминов и выражений без num отбрасываются
+func Build(request Request, proximityAmbit int) (map[string]interface{}, error) {
+	query, err := buildQuery(request, proximityAmbit)
+	if err != nil {
+		return nil, err
+	}
+
+	if Layout == structs.LayoutNested {
+		count := 0
+		nameInnerHits(query, &count)
+	}
+
+	return query, nil
+}
+
+func buildQuery(request Request, proximityAmbit int) (map[string]interface{}, error) {
+	if request.Expression != "" {
+		expression := request.expression
+		if expression == nil {
+			var err error
+			if expression, err = Parse(request.Expression); err != nil {
+				return nil, err
+			}
+		}
+
+		if expression.HasAnchor {
+			return expression.Root.Query(proximityAmbit), nil
+		}
+
+		return withNumberCenter(expression.Root.Query(proximityAmbit)), nil
+	}
+
+	return withNumberCenter(buildProximity(request.Word, Range{Min: request.Min, Max: request.Max, Unit: request.Unit}, request.Distance, request.Direction)), nil
+}
+
+// innerHitsSize Количество соседей, возвращаемых каждым nested-условием в inner_hits (предел Elasticsearch по умолчанию)
+const innerHitsSize = 100
+
+// nameInnerHits Функция добавляет к каждому nested-условию запроса inner_hits с уникальным именем, чтобы по найденным
+// соседям определить смещение слова (см. findNestedOffset). Условия внутри must_not найденных соседей не имеют и пропускаются
+func nameInnerHits(query interface{}, count *int) {
+	switch value := query.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if key == "must_not" {
+				continue
+			}
+
+			if nested, ok := child.(map[string]interface{}); ok && key == "nested" {
+				*count++
+				nested["inner_hits"] = map[string]interface{}{
+					"name": "neighbours_" + strconv.Itoa(*count),
+					"size": innerHitsSize,
+				}
+			}
+
+			nameInnerHits(child, count)
+		}
+	case []interface{}:
+		for _, child := range value {
+			nameInnerHits(child, count)
+		}
+	}
+}
+
+// withNumberCenter Функция добавляет к запросу условие, что центр окрестности - число (num) или диапазон (num_min..num_max)
+func withNumberCenter(query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": []interface{}{query},
+			"filter": []interface{}{
+				map[string]interface{}{
+					"bool": map[string]interface{}{
+						"should": []interface{}{
+							map[string]interface{}{"exists": map[string]interface{}{"field": "num"}},
+							map[string]interface{}{"exists": map[string]interface{}{"field": "num_min"}},
+						},
+						"minimum_should_match": 1,
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildProximity(word string, numRange Range, distance int, direction string) map[string]interface{} {
+	boolQuery := buildWord(word, distance, direction)["bool"].(map[string]interface{})
+
+	if rangeQuery := buildRange(numRange); rangeQuery != nil {
+		boolQuery["filter"] = []interface{}{rangeQuery}
+	}
+
+	return map[string]interface{}{
+		"bool": boolQuery,
+	}
+}
+
+// Anchor Центр окрестности, который не является числом: термин словаря (term) или именованное выражение (chemical, standard, ...).
+// Пустое значение означает любой якорь этого типа
+type Anchor struct {
+	Type  string
+	Value string
+}
+
+func buildAnchorProximity(word string, anchor Anchor, distance int, direction string) map[string]interface{} {
+	boolQuery := buildWord(word, distance, direction)["bool"].(map[string]interface{})
+	boolQuery["filter"] = []interface{}{buildAnchor(anchor)}
+
+	return map[string]interface{}{
+		"bool": boolQuery,
+	}
+}
+
+// buildAnchor Функция строит условие на якорь в центре окрестности (поля anchor_type и anchor)
+func buildAnchor(anchor Anchor) map[string]interface{} {
+	filter := []interface{}{buildMatch("anchor_type", anchor.Type)}
+	if anchor.Value != "" {
+		filter = append(filter, map[string]interface{}{
+			"match_phrase": map[string]interface{}{
+				"anchor": anchor.Value,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": filter,
+		},
+	}
+}
+
+func buildWord(word string, distance int, direction string) map[string]interface{} {
+	switch Layout {
+	case structs.LayoutNested:
+		return buildNestedWord(word, distance, direction)
+	case structs.LayoutPositions:
+		return buildSpanWord(word, distance, direction)
+	}
+
+	var should []interface{}
+	for i := 1; i <= maxPosition(distance); i++ {
+		needleIndex := strconv.Itoa(i)
+
+		if direction != DirectionAfter {
+			should = append(should, buildNeighbourMatch("b", needleIndex, word, distance))
+		}
+
+		if direction != DirectionBefore {
+			should = append(should, buildNeighbourMatch("a", needleIndex, word, distance))
+		}
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// maxPosition Функция возвращает наибольший номер соседа, который может находиться на данном расстоянии от числа.
+// При подсчете в символах номер соседа порядковый, а между соседями есть хотя бы один символ, поэтому номер не превышает distance + 1
+func maxPosition(distance int) int {
+	if Strategy == structs.StrategyChars {
+		return distance + 1
+	}
+
+	return distance
+}
+
+// buildNeighbourMatch Функция строит условие на соседа с номером needleIndex с одной стороны от числа (suffix - b или a).
+// При подсчете в символах дополнительно проверяется расстояние до соседа в символах (поле d?_N)
+func buildNeighbourMatch(suffix string, needleIndex string, word string, distance int) map[string]interface{} {
+	match := buildMatch("t"+suffix+"_"+needleIndex, word)
+	if Strategy != structs.StrategyChars {
+		return match
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": []interface{}{match},
+			"filter": []interface{}{
+				buildBound("d"+suffix+"_"+needleIndex, "lte", float64(distance)),
+			},
+		},
+	}
+}
+
+// buildNestedWord Функция строит условие на слово в nested-массиве neighbours: токен совпадает со словом,
+// а смещение не превышает расстояние с нужной стороны от числа. При подсчете в символах вместо смещения
+// (порядкового номера соседа) проверяется расстояние в символах neighbours.distance и сторона neighbours.side
+func buildNestedWord(word string, distance int, direction string) map[string]interface{} {
+	filter := []interface{}{buildNestedOffset(distance, direction)}
+	if Strategy == structs.StrategyChars {
+		filter = buildNestedDistance(distance, direction)
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": []interface{}{
+				map[string]interface{}{
+					"nested": map[string]interface{}{
+						"path": "neighbours",
+						"query": map[string]interface{}{
+							"bool": map[string]interface{}{
+								"must":   []interface{}{buildMatch("neighbours.token", word)},
+								"filter": filter,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildNestedOffset(distance int, direction string) map[string]interface{} {
+	offset := map[string]interface{}{
+		"gte": -distance,
+		"lte": distance,
+	}
+
+	switch direction {
+	case DirectionBefore:
+		offset["lte"] = -1
+	case DirectionAfter:
+		offset["gte"] = 1
+	}
+
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			"neighbours.offset": offset,
+		},
+	}
+}
+
+func buildNestedDistance(distance int, direction string) []interface{} {
+	filter := []interface{}{buildBound("neighbours.distance", "lte", float64(distance))}
+
+	switch direction {
+	case DirectionBefore:
+		filter = append(filter, buildTerm("neighbours.side", structs.SideBefore))
+	case DirectionAfter:
+		filter = append(filter, buildTerm("neighbours.side", structs.SideAfter))
+	}
+
+	return filter
+}
+
+// buildSpanWord Функция строит условие на слово в поле context: слово находится не дальше distance позиций
+// от structs.CenterToken. Для направлений before и after учитывается порядок слова и центра.
+// При подсчете в символах смещение соседа на единицу больше расстояния (см. structs.PositionOffset)
+func buildSpanWord(word string, distance int, direction string) map[string]interface{} {
+	wordTerm := buildSpanTerm(strings.ToLower(word))
+	centerTerm := buildSpanTerm(structs.CenterToken)
+
+	clauses := []interface{}{wordTerm, centerTerm}
+	if direction == DirectionAfter {
+		clauses = []interface{}{centerTerm, wordTerm}
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": []interface{}{
+				map[string]interface{}{
+					"span_near": map[string]interface{}{
+						"clauses":  clauses,
+						"slop":     maxPosition(distance) - 1,
+						"in_order": direction != DirectionAny,
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildSpanTerm(value string) map[string]interface{} {
+	return map[string]interface{}{
+		"span_term": map[string]interface{}{
+			"context": value,
+		},
+	}
+}
+
+// buildRange Функция строит условие на центральное число окрестности.
+// Одиночное число (num) должно попадать в диапазон, а диапазон или допуск (num_min..num_max) - пересекаться с ним
+func buildRange(numRange Range) map[string]interface{} {
+	if numRange.Min == nil && numRange.Max == nil {
+		return nil
+	}
+
+	if numRange.Unit != "" {
+		return buildUnitRange(numRange)
+	}
+
+	return buildBounds(numRange, "")
+}
+
+// buildUnitRange Функция строит условие на число с единицей измерения: сравнение в СИ в пределах той же категории единиц
+func buildUnitRange(numRange Range) map[string]interface{} {
+	unit, _ := units.Get(numRange.Unit)
+
+	siRange := numRange
+	if numRange.Min != nil {
+		min := unit.ToSI(*numRange.Min)
+		siRange.Min = &min
+	}
+	if numRange.Max != nil {
+		max := unit.ToSI(*numRange.Max)
+		siRange.Max = &max
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": []interface{}{
+				buildMatch("unit_category", unit.Category),
+				buildBounds(siRange, "_si"),
+			},
+		},
+	}
+}
+
+func buildBounds(numRange Range, suffix string) map[string]interface{} {
+	bounds := map[string]interface{}{}
+	var overlap []interface{}
+
+	if numRange.Min != nil {
+		operator := "gte"
+		if numRange.MinExclusive {
+			operator = "gt"
+		}
+
+		bounds[operator] = *numRange.Min
+		overlap = append(overlap, buildBound("num_max"+suffix, operator, *numRange.Min))
+	}
+	if numRange.Max != nil {
+		operator := "lte"
+		if numRange.MaxExclusive {
+			operator = "lt"
+		}
+
+		bounds[operator] = *numRange.Max
+		overlap = append(overlap, buildBound("num_min"+suffix, operator, *numRange.Max))
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				map[string]interface{}{
+					"range": map[string]interface{}{
+						"num" + suffix: bounds,
+					},
+				},
+				map[string]interface{}{
+					"bool": map[string]interface{}{
+						"filter": overlap,
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func buildBound(field string, operator string, value float64) map[string]interface{} {
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			field: map[string]interface{}{
+				operator: value,
+			},
+		},
+	}
+}
+
+func buildTerm(field string, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			field: value,
+		},
+	}
+}
+
+func buildMatch(field string, word string) map[string]interface{} {
+	return map[string]interface{}{
+		"match": map[string]interface{}{
+			field: word,
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"encoding/json"
+	"github.com/tidwall/gjson"
+	"strconv"
+	"testing"
+)
+
+// toJSON Функция кодирует запрос Elasticsearch в JSON для проверки через gjson
+func toJSON(t *testing.T, query map[string]interface{}) gjson.Result {
+	body, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v", err)
+	}
+
+	return gjson.ParseBytes(body)
+}
+
+// setIndexFormat Функция на время теста подменяет формат записи соседей и способ подсчета расстояния
+func setIndexFormat(t *testing.T, layout string, strategy string) {
+	previousLayout, previousStrategy := Layout, Strategy
+	t.Cleanup(func() { Layout, Strategy = previousLayout, previousStrategy })
+
+	Layout, Strategy = layout, strategy
+}
+
+func TestMaxPosition(t *testing.T) {
+	tests := []struct {
+		strategy string
+		distance int
+		expected int
+	}{
+		{structs.StrategyTokens, 5, 5},
+		{structs.StrategyWords, 5, 5},
+		{structs.StrategyChars, 5, 6},
+	}
+
+	for _, test := range tests {
+		setIndexFormat(t, structs.LayoutFlat, test.strategy)
+
+		if actual := maxPosition(test.distance); actual != test.expected {
+			t.Errorf("maxPosition(%d) при %s = %d, ожидалось %d", test.distance, test.strategy, actual, test.expected)
+		}
+	}
+}
+
+func TestBuildWordFlat(t *testing.T) {
+	setIndexFormat(t, structs.LayoutFlat, structs.StrategyTokens)
+
+	should := toJSON(t, buildWord("steel", 3, DirectionBefore)).Get("bool.should").Array()
+	if len(should) != 3 {
+		t.Fatalf("buildWord() = %v, ожидалось 3 условия", should)
+	}
+
+	for i, clause := range should {
+		if field := "tb_" + strconv.Itoa(i+1); clause.Get("match."+field).String() != "steel" {
+			t.Errorf("условие %d = %s, ожидалось совпадение поля %s", i, clause.Raw, field)
+		}
+	}
+}
+
+func TestBuildWordFlatChars(t *testing.T) {
+	setIndexFormat(t, structs.LayoutFlat, structs.StrategyChars)
+
+	should := toJSON(t, buildWord("steel", 3, DirectionAny)).Get("bool.should").Array()
+	if len(should) != 2*maxPosition(3) {
+		t.Fatalf("buildWord() = %d условий, ожидалось %d", len(should), 2*maxPosition(3))
+	}
+
+	last := should[len(should)-1]
+	if last.Get("bool.must.0.match.ta_4").String() != "steel" || last.Get("bool.filter.0.range.da_4.lte").Int() != 3 {
+		t.Errorf("условие на соседа ta_4 = %s, ожидалось ограничение расстояния da_4 <= 3", last.Raw)
+	}
+}
+
+func TestBuildWordNested(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		direction string
+		filter    string
+	}{
+		{structs.StrategyTokens, DirectionAny, `[{"range":{"neighbours.offset":{"gte":-3,"lte":3}}}]`},
+		{structs.StrategyTokens, DirectionAfter, `[{"range":{"neighbours.offset":{"gte":1,"lte":3}}}]`},
+		{structs.StrategyChars, DirectionAny, `[{"range":{"neighbours.distance":{"lte":3}}}]`},
+		{structs.StrategyChars, DirectionBefore, `[{"range":{"neighbours.distance":{"lte":3}}},{"term":{"neighbours.side":"` + structs.SideBefore + `"}}]`},
+	}
+
+	for _, test := range tests {
+		setIndexFormat(t, structs.LayoutNested, test.strategy)
+
+		filter := toJSON(t, buildWord("steel", 3, test.direction)).Get("bool.must.0.nested.query.bool.filter")
+		if filter.Raw != test.filter {
+			t.Errorf("buildWord(%s, %s) filter = %s, ожидалось %s", test.strategy, test.direction, filter.Raw, test.filter)
+		}
+	}
+}
+
+func TestBuildWordPositions(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		direction string
+		slop      int64
+		inOrder   bool
+		first     string
+	}{
+		{structs.StrategyTokens, DirectionAny, 2, false, "steel"},
+		{structs.StrategyTokens, DirectionAfter, 2, true, structs.CenterToken},
+		{structs.StrategyChars, DirectionBefore, 3, true, "steel"},
+	}
+
+	for _, test := range tests {
+		setIndexFormat(t, structs.LayoutPositions, test.strategy)
+
+		spanNear := toJSON(t, buildWord("Steel", 3, test.direction)).Get("bool.must.0.span_near")
+		if spanNear.Get("slop").Int() != test.slop || spanNear.Get("in_order").Bool() != test.inOrder || spanNear.Get("clauses.0.span_term.context").String() != test.first {
+			t.Errorf("buildWord(%s, %s) = %s", test.strategy, test.direction, spanNear.Raw)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	setIndexFormat(t, structs.LayoutFlat, structs.StrategyTokens)
+
+	request := Request{Word: "temperature", Min: float(100), Max: float(200), Distance: 2, Direction: DirectionAfter, Size: 10}
+	query, err := Build(request, 5)
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+
+	j := toJSON(t, query)
+	if j.Get("bool.filter.0.bool.should.#").Int() != 2 || j.Get("bool.filter.0.bool.should.0.exists.field").String() != "num" {
+		t.Errorf("Build(): нет условия на числовой центр окрестности: %s", j.Raw)
+	}
+
+	proximity := j.Get("bool.must.0.bool")
+	if proximity.Get("should.#").Int() != 2 || proximity.Get("should.0.match.ta_1").String() != "temperature" || proximity.Get("should.1.match.ta_2").String() != "temperature" {
+		t.Errorf("Build(): условия на соседей %s", proximity.Get("should").Raw)
+	}
+
+	bounds := proximity.Get("filter.0.bool.should.0.range.num")
+	if bounds.Get("gte").Float() != 100 || bounds.Get("lte").Float() != 200 {
+		t.Errorf("Build(): границы диапазона %s", bounds.Raw)
+	}
+
+	overlap := proximity.Get("filter.0.bool.should.1.bool.filter")
+	if overlap.Get("0.range.num_max.gte").Float() != 100 || overlap.Get("1.range.num_min.lte").Float() != 200 {
+		t.Errorf("Build(): пересечение с диапазоном окрестности %s", overlap.Raw)
+	}
+}
+
+func TestBuildRange(t *testing.T) {
+	if buildRange(Range{}) != nil {
+		t.Errorf("buildRange() без границ = не nil")
+	}
+
+	exclusive := toJSON(t, buildRange(Range{Min: float(1), Max: float(2), MinExclusive: true, MaxExclusive: true}))
+	if !exclusive.Get("bool.should.0.range.num.gt").Exists() || !exclusive.Get("bool.should.0.range.num.lt").Exists() {
+		t.Errorf("buildRange() с исключенными границами = %s", exclusive.Raw)
+	}
+
+	unit := toJSON(t, buildRange(Range{Min: float(1), Unit: "mm"}))
+	if unit.Get("bool.filter.0.match.unit_category").String() == "" || unit.Get("bool.filter.1.bool.should.0.range.num_si.gte").Float() != 0.001 {
+		t.Errorf("buildRange() с единицей измерения = %s", unit.Raw)
+	}
+}
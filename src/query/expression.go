@@ -0,0 +1,104 @@
+package query
+
+// Expression Разобранное выражение на языке запросов
+type Expression struct {
+	Root Node
+	// Words Все слова, встречающиеся в выражении
+	Words []string
+	// MaxDistance Максимальное расстояние, указанное в операторах NEAR/BEFORE/AFTER
+	MaxDistance int
+	// HasAnchor В выражении есть якорь (@type): поиск идет и по окрестностям якорей, а не только чисел
+	HasAnchor bool
+}
+
+// Node Узел дерева выражения, который умеет превращаться в запрос Elasticsearch
+type Node interface {
+	Query(proximityAmbit int) map[string]interface{}
+}
+
+// WordNode Слово без оператора близости: ищется во всей окрестности числа
+type WordNode struct {
+	Word string
+}
+
+func (n *WordNode) Query(proximityAmbit int) map[string]interface{} {
+	return buildWord(n.Word, proximityAmbit, DirectionAny)
+}
+
+// RangeNode Диапазон без оператора близости: ограничивает только центральное число окрестности
+type RangeNode struct {
+	Range Range
+}
+
+func (n *RangeNode) Query(proximityAmbit int) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": []interface{}{buildRange(n.Range)},
+		},
+	}
+}
+
+// AnchorNode Якорь без оператора близости: ограничивает только центр окрестности
+type AnchorNode struct {
+	Anchor Anchor
+}
+
+func (n *AnchorNode) Query(proximityAmbit int) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": []interface{}{buildAnchor(n.Anchor)},
+		},
+	}
+}
+
+// ProximityNode Слово на расстоянии не более Distance от числа из диапазона (или от якоря, если он указан).
+// Нулевое расстояние (оператор без "/k") означает всю окрестность
+type ProximityNode struct {
+	Word      string
+	Range     Range
+	Anchor    *Anchor
+	Distance  int
+	Direction string
+}
+
+func (n *ProximityNode) Query(proximityAmbit int) map[string]interface{} {
+	distance := n.Distance
+	if distance == 0 {
+		distance = proximityAmbit
+	}
+
+	if n.Anchor != nil {
+		return buildAnchorProximity(n.Word, *n.Anchor, distance, n.Direction)
+	}
+
+	return buildProximity(n.Word, n.Range, distance, n.Direction)
+}
+
+// BoolNode Логическое объединение узлов.
+// Условия проверяются в пределах одной окрестности, т.е. AND требует, чтобы все условия выполнялись для одного и того же числа
+type BoolNode struct {
+	Operator string
+	Children []Node
+}
+
+func (n *BoolNode) Query(proximityAmbit int) map[string]interface{} {
+	var children []interface{}
+	for _, child := range n.Children {
+		children = append(children, child.Query(proximityAmbit))
+	}
+
+	if n.Operator == operatorAnd {
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": children,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               children,
+			"minimum_should_match": 1,
+		},
+	}
+}
@@ -0,0 +1,387 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/units"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	operatorAnd    = "AND"
+	operatorOr     = "OR"
+	operatorNear   = "NEAR"
+	operatorBefore = "BEFORE"
+	operatorAfter  = "AFTER"
+)
+
+const (
+	tokenEOF = iota
+	tokenWord
+	tokenNumber
+	tokenRange
+	tokenAnchor
+	tokenAnd
+	tokenOr
+	tokenProximity
+	tokenLeftParen
+	tokenRightParen
+)
+
+var (
+	numberPattern = `-?[0-9]+(?:[.,][0-9]+)?(?:[eE][-+]?[0-9]+)?`
+	rangeRe       = regexp.MustCompile(`^([\[(])\s*(` + numberPattern + `)?\s*\.\.\s*(` + numberPattern + `)?\s*([^\s\])][^\])]*?)?\s*([\])])`)
+	numberRe      = regexp.MustCompile(`^` + numberPattern)
+	anchorRe      = regexp.MustCompile(`^@([\p{L}\p{N}_-]+)(?::(?:"([^"]*)"|([^\s()"]+)))?`)
+)
+
+// ParseError Ошибка разбора выражения с указанием позиции (в символах, начиная с 1)
+type ParseError struct {
+	Position int
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("ошибка в выражении, позиция %d: %s", e.Position, e.Message)
+}
+
+type token struct {
+	kind     int
+	text     string
+	position int
+	number   float64
+	numRange Range
+	anchor   Anchor
+	unit     string
+	operator string
+	distance int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "конец выражения"
+	}
+
+	return `"` + t.text + `"`
+}
+
+// Parse Функция разбирает выражение на языке запросов.
+// Грамматика:
+//
+//	выражение  = и { "OR" и }
+//	и          = близость { "AND" близость }
+//	близость   = операнд [ ("NEAR" | "BEFORE" | "AFTER") ["/" k] операнд ]
+//	операнд    = слово | число | диапазон | якорь | "(" выражение ")"
+//	число      = цифры [единица]
+//	диапазон   = ("[" | "(") [цифры] ".." [цифры] [единица] ("]" | ")")
+//	якорь      = "@" тип [":" (значение | '"' значение '"')]
+//
+// Пример: temperature NEAR/5 [100..200) OR (thickness BEFORE/3 [0.5..2 mm] AND 500 µm)
+// Пример с якорем: coating NEAR/5 @chemical:SiO2 OR @standard:"ISO 9001"
+func Parse(input string) (*Expression, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, expression: &Expression{}}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if current := p.peek(); current.kind != tokenEOF {
+		return nil, &ParseError{current.position, "ожидался оператор AND или OR, получено " + current.String()}
+	}
+
+	p.expression.Root = root
+
+	return p.expression, nil
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+
+	position := func(offset int) int {
+		return utf8.RuneCountInString(input[:offset]) + 1
+	}
+
+	for offset := 0; offset < len(input); {
+		r, size := utf8.DecodeRuneInString(input[offset:])
+		rest := input[offset:]
+
+		switch {
+		case unicode.IsSpace(r):
+			offset += size
+			continue
+		case r == '[' || r == '(':
+			if match := rangeRe.FindStringSubmatch(rest); match != nil {
+				numRange := Range{MinExclusive: match[1] == "(", MaxExclusive: match[5] == ")", Unit: match[4]}
+
+				if _, ok := units.Get(numRange.Unit); numRange.Unit != "" && !ok {
+					return nil, &ParseError{position(offset), "неизвестная единица измерения \"" + numRange.Unit + "\""}
+				}
+
+				if match[2] != "" {
+					min := parseNumber(match[2])
+					numRange.Min = &min
+				}
+				if match[3] != "" {
+					max := parseNumber(match[3])
+					numRange.Max = &max
+				}
+
+				if numRange.Min == nil && numRange.Max == nil {
+					return nil, &ParseError{position(offset), "у диапазона должна быть указана хотя бы одна граница"}
+				}
+				if numRange.Min != nil && numRange.Max != nil && *numRange.Min > *numRange.Max {
+					return nil, &ParseError{position(offset), "нижняя граница диапазона больше верхней"}
+				}
+
+				tokens = append(tokens, token{kind: tokenRange, text: match[0], position: position(offset), numRange: numRange})
+				offset += len(match[0])
+				continue
+			}
+
+			if r == '[' {
+				return nil, &ParseError{position(offset), "некорректный диапазон, ожидалось [min..max]"}
+			}
+
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", position: position(offset)})
+			offset += size
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", position: position(offset)})
+			offset += size
+		case r == '@':
+			match := anchorRe.FindStringSubmatch(rest)
+			if match == nil {
+				return nil, &ParseError{position(offset), "некорректный якорь, ожидалось @тип или @тип:значение"}
+			}
+
+			anchor := Anchor{Type: match[1], Value: match[2] + match[3]}
+			tokens = append(tokens, token{kind: tokenAnchor, text: match[0], position: position(offset), anchor: anchor})
+			offset += len(match[0])
+		case unicode.IsDigit(r) || r == '-':
+			match := numberRe.FindString(rest)
+			if match == "" {
+				return nil, &ParseError{position(offset), "неожиданный символ \"" + string(r) + "\""}
+			}
+
+			current := token{kind: tokenNumber, text: match, position: position(offset), number: parseNumber(match)}
+			offset += len(match)
+
+			// Единица измерения после числа: 500 µm, 5mm
+			afterSpaces := strings.TrimLeft(input[offset:], " ")
+			if _, length, ok := units.Match(afterSpaces); ok {
+				current.unit = afterSpaces[:length]
+				current.text = input[offset-len(match) : len(input)-len(afterSpaces)+length]
+				offset = len(input) - len(afterSpaces) + length
+			}
+
+			tokens = append(tokens, current)
+		case unicode.IsLetter(r):
+			end := offset
+			for end < len(input) {
+				next, nextSize := utf8.DecodeRuneInString(input[end:])
+				if !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_' && next != '-' {
+					break
+				}
+				end += nextSize
+			}
+
+			word := input[offset:end]
+			current := token{kind: tokenWord, text: word, position: position(offset)}
+
+			switch word {
+			case operatorAnd:
+				current.kind = tokenAnd
+			case operatorOr:
+				current.kind = tokenOr
+			case operatorNear, operatorBefore, operatorAfter:
+				current.kind = tokenProximity
+				current.operator = word
+
+				if end < len(input) && input[end] == '/' {
+					digits := end + 1
+					for digits < len(input) && input[digits] >= '0' && input[digits] <= '9' {
+						digits++
+					}
+
+					distance, err := strconv.Atoi(input[end+1 : digits])
+					if err != nil || distance < 1 {
+						return nil, &ParseError{position(end), "после \"" + word + "/\" ожидалось положительное целое расстояние"}
+					}
+
+					current.distance = distance
+					end = digits
+				}
+
+				current.text = input[offset:end]
+			}
+
+			tokens = append(tokens, current)
+			offset = end
+		default:
+			return nil, &ParseError{position(offset), "неожиданный символ \"" + string(r) + "\""}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, position: position(len(input))}), nil
+}
+
+func parseNumber(text string) float64 {
+	num, _ := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+
+	return num
+}
+
+type parser struct {
+	tokens     []token
+	current    int
+	expression *Expression
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.current]
+}
+
+func (p *parser) next() token {
+	current := p.tokens[p.current]
+	if current.kind != tokenEOF {
+		p.current++
+	}
+
+	return current
+}
+
+func (p *parser) parseOr() (Node, error) {
+	return p.parseBinary(tokenOr, operatorOr, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	return p.parseBinary(tokenAnd, operatorAnd, p.parseProximity)
+}
+
+func (p *parser) parseBinary(kind int, operator string, operand func() (Node, error)) (Node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []Node{left}
+	for p.peek().kind == kind {
+		p.next()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, right)
+	}
+
+	if len(children) == 1 {
+		return left, nil
+	}
+
+	return &BoolNode{Operator: operator, Children: children}, nil
+}
+
+func (p *parser) parseProximity() (Node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenProximity {
+		return left, nil
+	}
+
+	operator := p.next()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ProximityNode{Distance: operator.distance, Direction: DirectionAny}
+
+	leftWord, leftIsWord := left.(*WordNode)
+	rightWord, rightIsWord := right.(*WordNode)
+	leftRange, leftIsRange := left.(*RangeNode)
+	rightRange, rightIsRange := right.(*RangeNode)
+	leftAnchor, leftIsAnchor := left.(*AnchorNode)
+	rightAnchor, rightIsAnchor := right.(*AnchorNode)
+
+	switch {
+	case leftIsWord && rightIsRange:
+		node.Word, node.Range = leftWord.Word, rightRange.Range
+	case leftIsRange && rightIsWord:
+		node.Word, node.Range = rightWord.Word, leftRange.Range
+	case leftIsWord && rightIsAnchor:
+		node.Word, node.Anchor = leftWord.Word, &rightAnchor.Anchor
+	case leftIsAnchor && rightIsWord:
+		node.Word, node.Anchor = rightWord.Word, &leftAnchor.Anchor
+	default:
+		return nil, &ParseError{operator.position, "оператор " + operator.text + " должен связывать одно слово и одно число, диапазон или якорь"}
+	}
+
+	// "X BEFORE Y" означает, что X стоит перед Y. Направление хранится относительно слова
+	switch operator.operator {
+	case operatorBefore:
+		if leftIsWord {
+			node.Direction = DirectionBefore
+		} else {
+			node.Direction = DirectionAfter
+		}
+	case operatorAfter:
+		if leftIsWord {
+			node.Direction = DirectionAfter
+		} else {
+			node.Direction = DirectionBefore
+		}
+	}
+
+	if node.Distance > p.expression.MaxDistance {
+		p.expression.MaxDistance = node.Distance
+	}
+
+	return node, nil
+}
+
+func (p *parser) parseOperand() (Node, error) {
+	current := p.next()
+
+	switch current.kind {
+	case tokenWord:
+		p.expression.Words = append(p.expression.Words, current.text)
+
+		return &WordNode{Word: current.text}, nil
+	case tokenNumber:
+		num := current.number
+
+		return &RangeNode{Range: Range{Min: &num, Max: &num, Unit: current.unit}}, nil
+	case tokenRange:
+		return &RangeNode{Range: current.numRange}, nil
+	case tokenAnchor:
+		p.expression.HasAnchor = true
+		return &AnchorNode{Anchor: current.anchor}, nil
+	case tokenLeftParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, &ParseError{closing.position, fmt.Sprintf("ожидалась \")\" для скобки из позиции %d, получено %s", current.position, closing.String())}
+		}
+
+		return node, nil
+	default:
+		return nil, &ParseError{current.position, "ожидалось слово, число, диапазон или якорь, получено " + current.String()}
+	}
+}
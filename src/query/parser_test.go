@@ -0,0 +1,144 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input       string
+		root        Node
+		words       []string
+		maxDistance int
+	}{
+		{
+			input: "temperature NEAR/5 [100..200]",
+			root: &ProximityNode{
+				Word:      "temperature",
+				Range:     Range{Min: float(100), Max: float(200)},
+				Distance:  5,
+				Direction: DirectionAny,
+			},
+			words:       []string{"temperature"},
+			maxDistance: 5,
+		},
+		{
+			input: "[0.5..2 mm) AFTER/3 thickness",
+			root: &ProximityNode{
+				Word:      "thickness",
+				Range:     Range{Min: float(0.5), Max: float(2), MaxExclusive: true, Unit: "mm"},
+				Distance:  3,
+				Direction: DirectionBefore,
+			},
+			words:       []string{"thickness"},
+			maxDistance: 3,
+		},
+		{
+			input: "pressure BEFORE [..10]",
+			root: &ProximityNode{
+				Word:      "pressure",
+				Range:     Range{Max: float(10)},
+				Direction: DirectionBefore,
+			},
+			words: []string{"pressure"},
+		},
+		{
+			input: "a NEAR/2 5 OR (b AFTER/7 (1..) AND c)",
+			root: &BoolNode{Operator: operatorOr, Children: []Node{
+				&ProximityNode{Word: "a", Range: Range{Min: float(5), Max: float(5)}, Distance: 2, Direction: DirectionAny},
+				&BoolNode{Operator: operatorAnd, Children: []Node{
+					&ProximityNode{Word: "b", Range: Range{Min: float(1), MinExclusive: true, MaxExclusive: true}, Distance: 7, Direction: DirectionAfter},
+					&WordNode{Word: "c"},
+				}},
+			}},
+			words:       []string{"a", "b", "c"},
+			maxDistance: 7,
+		},
+		{
+			input: `coating NEAR/5 @standard:"ISO 9001"`,
+			root: &ProximityNode{
+				Word:      "coating",
+				Anchor:    &Anchor{Type: "standard", Value: "ISO 9001"},
+				Distance:  5,
+				Direction: DirectionAny,
+			},
+			words:       []string{"coating"},
+			maxDistance: 5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			expression, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) вернула ошибку: %v", test.input, err)
+			}
+
+			if !reflect.DeepEqual(expression.Root, test.root) {
+				t.Errorf("Parse(%q).Root = %#v, ожидалось %#v", test.input, expression.Root, test.root)
+			}
+
+			if !reflect.DeepEqual(expression.Words, test.words) {
+				t.Errorf("Parse(%q).Words = %v, ожидалось %v", test.input, expression.Words, test.words)
+			}
+
+			if expression.MaxDistance != test.maxDistance {
+				t.Errorf("Parse(%q).MaxDistance = %d, ожидалось %d", test.input, expression.MaxDistance, test.maxDistance)
+			}
+		})
+	}
+}
+
+func TestParseHasAnchor(t *testing.T) {
+	tests := []struct {
+		input     string
+		hasAnchor bool
+	}{
+		{"temperature NEAR/5 [100..200]", false},
+		{"coating NEAR @chemical", true},
+		{"@standard AND 100", true},
+	}
+
+	for _, test := range tests {
+		expression, err := Parse(test.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) вернула ошибку: %v", test.input, err)
+		}
+
+		if expression.HasAnchor != test.hasAnchor {
+			t.Errorf("Parse(%q).HasAnchor = %v, ожидалось %v", test.input, expression.HasAnchor, test.hasAnchor)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input    string
+		position int
+	}{
+		{"temperature NEAR/5", 19},
+		{"temperature NEAR pressure", 13},
+		{"(temperature NEAR [1..2]", 25},
+		{"temperature [1..2]", 13},
+		{"", 1},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.input)
+
+		parseError, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse(%q) вернула ошибку %v, ожидалась ParseError", test.input, err)
+			continue
+		}
+
+		if parseError.Position != test.position {
+			t.Errorf("Parse(%q): позиция ошибки %d, ожидалась %d (%s)", test.input, parseError.Position, test.position, parseError.Message)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/units"
+	"errors"
+	"fmt"
+)
+
+const (
+	DirectionAny    = "any"
+	DirectionBefore = "before"
+	DirectionAfter  = "after"
+)
+
+// Request Параметры поиска числа из диапазона рядом со словом.
+// Вместо отдельных параметров может быть указано выражение на языке запросов (см. Parse)
+type Request struct {
+	Expression string
+	Word       string
+	Min        *float64
+	Max        *float64
+	Unit       string
+	Distance   int
+	Direction  string
+	Language   string
+	Size       int
+
+	// expression Разобранное выражение, заполняется в Validate
+	expression *Expression
+}
+
+// Validate Функция для проверки корректности запроса относительно размерности окрестности, по которой строился индекс.
+// Выражение разбирается один раз и сохраняется в запросе для Build и Search
+func (r *Request) Validate(proximityAmbit int) error {
+	if r.Size < 1 {
+		return fmt.Errorf("размер выдачи должен быть положительным, указано: %d", r.Size)
+	}
+
+	if r.Expression != "" {
+		if r.expression == nil {
+			expression, err := Parse(r.Expression)
+			if err != nil {
+				return err
+			}
+
+			r.expression = expression
+		}
+
+		if r.expression.MaxDistance > proximityAmbit {
+			return fmt.Errorf("расстояние в выражении не может превышать размерность окрестности %d, указано: %d", proximityAmbit, r.expression.MaxDistance)
+		}
+
+		return nil
+	}
+
+	if r.Word == "" {
+		return errors.New("не указано слово для поиска")
+	}
+
+	if _, ok := units.Get(r.Unit); r.Unit != "" && !ok {
+		return fmt.Errorf("неизвестная единица измерения: %s", r.Unit)
+	}
+
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return fmt.Errorf("нижняя граница диапазона (%g) больше верхней (%g)", *r.Min, *r.Max)
+	}
+
+	if r.Distance < 1 || r.Distance > proximityAmbit {
+		return fmt.Errorf("расстояние должно быть в пределах [1..%d], указано: %d", proximityAmbit, r.Distance)
+	}
+
+	switch r.Direction {
+	case DirectionAny, DirectionBefore, DirectionAfter:
+	default:
+		return fmt.Errorf("неизвестное направление: %s (допустимо: %s, %s, %s)", r.Direction, DirectionAny, DirectionBefore, DirectionAfter)
+	}
+
+	return nil
+}
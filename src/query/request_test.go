@@ -0,0 +1,31 @@
+package query
+
+import "testing"
+
+func TestRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request Request
+		ok      bool
+	}{
+		{"слово и диапазон", Request{Word: "temperature", Min: float(100), Max: float(200), Distance: 5, Direction: DirectionAny, Size: 10}, true},
+		{"единица измерения", Request{Word: "temperature", Unit: "mm", Distance: 5, Direction: DirectionBefore, Size: 10}, true},
+		{"выражение", Request{Expression: "temperature NEAR/5 [100..200]", Size: 10}, true},
+		{"нет слова", Request{Distance: 5, Direction: DirectionAny, Size: 10}, false},
+		{"нулевой размер выдачи", Request{Word: "temperature", Distance: 5, Direction: DirectionAny}, false},
+		{"неизвестная единица", Request{Word: "temperature", Unit: "parsec-per-hour", Distance: 5, Direction: DirectionAny, Size: 10}, false},
+		{"перевернутый диапазон", Request{Word: "temperature", Min: float(200), Max: float(100), Distance: 5, Direction: DirectionAny, Size: 10}, false},
+		{"нулевое расстояние", Request{Word: "temperature", Direction: DirectionAny, Size: 10}, false},
+		{"расстояние больше окрестности", Request{Word: "temperature", Distance: 6, Direction: DirectionAny, Size: 10}, false},
+		{"неизвестное направление", Request{Word: "temperature", Distance: 5, Direction: "above", Size: 10}, false},
+		{"некорректное выражение", Request{Expression: "temperature NEAR/5", Size: 10}, false},
+		{"расстояние в выражении больше окрестности", Request{Expression: "temperature NEAR/6 [100..200]", Size: 10}, false},
+	}
+
+	for _, test := range tests {
+		request := test.request
+		if err := request.Validate(5); (err == nil) != test.ok {
+			t.Errorf("Validate(%s) = %v, ожидалось ok=%v", test.name, err, test.ok)
+		}
+	}
+}
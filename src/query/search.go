@@ -0,0 +1,309 @@
+package query
+
+import (
+	"bytes"
+	"elastic-proximity-calculation/src/helpers"
+	"elastic-proximity-calculation/src/structs"
+	"encoding/json"
+	"errors"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hit Одна найденная окрестность
+type Hit struct {
+	Index       string  `json:"index"`
+	SourceIndex string  `json:"source_index"`
+	SourceId    string  `json:"source_id"`
+	SourceField string  `json:"source_field"`
+	Num         float64 `json:"num"`
+	Expression  string  `json:"expression,omitempty"`
+	Offset      int     `json:"offset"`
+	Context     string  `json:"context"`
+	Score       float64 `json:"score"`
+}
+
+// Search Функция выполняет поиск по индексам окрестностей и возвращает найденные окрестности вместе с их контекстом
+func Search(client *elasticsearch.Client, target Target, request Request) ([]Hit, error) {
+	proximityAmbit := target.Ambit()
+	if err := request.Validate(proximityAmbit); err != nil {
+		return nil, err
+	}
+
+	language := request.Language
+	if language == "" {
+		language = "*"
+	}
+
+	esQuery, err := Build(request, proximityAmbit)
+	if err != nil {
+		return nil, err
+	}
+
+	searchBody := map[string]interface{}{
+		"query": esQuery,
+	}
+	if Layout == structs.LayoutFlat {
+		searchBody["highlight"] = map[string]interface{}{
+			"require_field_match": true,
+			"number_of_fragments": 0,
+			"fields": map[string]interface{}{
+				"tb_*": map[string]interface{}{},
+				"ta_*": map[string]interface{}{},
+			},
+		}
+	}
+
+	body, err := json.Marshal(searchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	words, distance, direction := []string{request.Word}, request.Distance, request.Direction
+	if request.Expression != "" {
+		words, distance, direction = request.expression.Words, proximityAmbit, DirectionAny
+	}
+
+	res, err := client.Search(
+		client.Search.WithIndex(target.IndexNames(language)...),
+		client.Search.WithBody(bytes.NewReader(body)),
+		client.Search.WithSize(request.Size),
+		client.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	j := helpers.ReaderToString(res.Body)
+	if res.IsError() {
+		return nil, errors.New("ошибка в ответе от Elasticsearch: " + j)
+	}
+
+	var hits []Hit
+	for _, hit := range gjson.Get(j, "hits.hits").Array() {
+		source := hit.Get("_source")
+
+		hits = append(hits, Hit{
+			Index:       hit.Get("_index").String(),
+			SourceIndex: source.Get("source_index").String(),
+			SourceId:    source.Get("source_id").String(),
+			SourceField: source.Get("source_field").String(),
+			Num:         source.Get("num").Float(),
+			Expression:  source.Get("expression").String(),
+			Offset:      findOffset(hit, words, distance, direction),
+			Context:     buildContext(source, proximityAmbit),
+			Score:       hit.Get("_score").Float(),
+		})
+	}
+
+	return hits, nil
+}
+
+// findOffset Функция возвращает ближайшее к числу смещение, на котором найдено одно из искомых слов.
+// Отрицательное смещение означает, что слово стоит перед числом. При подсчете в символах смещение - порядковый номер соседа.
+// Слова сравниваются с соседями так же, как в запросе - анализатором поля: в формате structs.LayoutFlat по подсветке
+// полей tb_N/ta_N, в формате structs.LayoutNested - по соседям из inner_hits
+func findOffset(hit gjson.Result, words []string, distance int, direction string) int {
+	source := hit.Get("_source")
+
+	if source.Get("neighbours").Exists() {
+		return findNestedOffset(hit.Get("inner_hits"), direction)
+	}
+
+	if context := source.Get("context"); context.Exists() {
+		return findPositionsOffset(context, words, distance, direction)
+	}
+
+	offset := 0
+	hit.Get("highlight").ForEach(func(field gjson.Result, _ gjson.Result) bool {
+		current, ok := parseNeighbourField(field.String())
+		if !ok || current == 0 || (current < 0 && direction == DirectionAfter) || (current > 0 && direction == DirectionBefore) {
+			return true
+		}
+
+		if Strategy == structs.StrategyChars && source.Get("d"+field.String()[1:]).Int() > int64(distance) {
+			return true
+		}
+
+		if offset == 0 || abs(current) < abs(offset) || (abs(current) == abs(offset) && current < offset) {
+			offset = current
+		}
+
+		return true
+	})
+
+	return offset
+}
+
+// parseNeighbourField Функция возвращает смещение соседа по имени поля tb_N (-N) или ta_N (N)
+func parseNeighbourField(field string) (int, bool) {
+	if len(field) < 4 || field[0] != 't' || field[2] != '_' {
+		return 0, false
+	}
+
+	position, err := strconv.Atoi(field[3:])
+	if err != nil {
+		return 0, false
+	}
+
+	switch field[1] {
+	case 'b':
+		return -position, true
+	case 'a':
+		return position, true
+	}
+
+	return 0, false
+}
+
+// findNestedOffset Функция аналогична findOffset для соседей из inner_hits nested-условий запроса (см. nameInnerHits).
+// Найденные соседи уже удовлетворяют условию на расстояние, поэтому выбирается ближайший к числу
+func findNestedOffset(innerHits gjson.Result, direction string) int {
+	offset := 0
+
+	innerHits.ForEach(func(_ gjson.Result, innerHit gjson.Result) bool {
+		for _, neighbour := range innerHit.Get("hits.hits").Array() {
+			current := int(neighbour.Get("_source.offset").Int())
+			if current == 0 || (current < 0 && direction == DirectionAfter) || (current > 0 && direction == DirectionBefore) {
+				continue
+			}
+
+			if offset == 0 || abs(current) < abs(offset) || (abs(current) == abs(offset) && current < offset) {
+				offset = current
+			}
+		}
+
+		return true
+	})
+
+	return offset
+}
+
+// findPositionsOffset Функция аналогична findOffset для соседей в текстовом поле context (structs.LayoutPositions).
+// Смещение - номер соседа: позиции structs.GapToken не учитываются
+func findPositionsOffset(context gjson.Result, words []string, distance int, direction string) int {
+	tokens := context.Array()
+
+	center := 0
+	for i, token := range tokens {
+		if token.String() == structs.CenterToken {
+			center = i
+			break
+		}
+	}
+
+	before, after := 0, 0
+	for i := 1; i <= maxPosition(distance); i++ {
+		left := center-i >= 0 && tokens[center-i].String() != structs.GapToken
+		if left {
+			before++
+		}
+
+		right := center+i < len(tokens) && tokens[center+i].String() != structs.GapToken
+		if right {
+			after++
+		}
+
+		for _, word := range words {
+			if direction != DirectionAfter && left && strings.EqualFold(tokens[center-i].String(), word) {
+				return -before
+			}
+
+			if direction != DirectionBefore && right && strings.EqualFold(tokens[center+i].String(), word) {
+				return after
+			}
+		}
+	}
+
+	return 0
+}
+
+// buildContext Функция восстанавливает текст окрестности: токены слева, центральное число, токены справа
+func buildContext(source gjson.Result, proximityAmbit int) string {
+	if neighbours := source.Get("neighbours"); neighbours.Exists() {
+		return buildNestedContext(source, neighbours)
+	}
+
+	if context := source.Get("context"); context.Exists() {
+		return buildPositionsContext(source, context)
+	}
+
+	var context []string
+
+	for i := maxPosition(proximityAmbit); i >= 1; i-- {
+		if token := source.Get("tb_" + strconv.Itoa(i)); token.Exists() {
+			context = append(context, token.String())
+		}
+	}
+
+	context = append(context, formatCenter(source))
+
+	for i := 1; i <= maxPosition(proximityAmbit); i++ {
+		if token := source.Get("ta_" + strconv.Itoa(i)); token.Exists() {
+			context = append(context, token.String())
+		}
+	}
+
+	return strings.Join(context, " ")
+}
+
+// buildNestedContext Функция аналогична buildContext для соседей в nested-массиве neighbours
+func buildNestedContext(source gjson.Result, neighbours gjson.Result) string {
+	items := neighbours.Array()
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Get("offset").Int() < items[j].Get("offset").Int()
+	})
+
+	var context []string
+	center := false
+
+	for _, neighbour := range items {
+		if !center && neighbour.Get("offset").Int() > 0 {
+			context = append(context, formatCenter(source))
+			center = true
+		}
+
+		context = append(context, neighbour.Get("token").String())
+	}
+
+	if !center {
+		context = append(context, formatCenter(source))
+	}
+
+	return strings.Join(context, " ")
+}
+
+// buildPositionsContext Функция аналогична buildContext для соседей в текстовом поле context (structs.LayoutPositions)
+func buildPositionsContext(source gjson.Result, context gjson.Result) string {
+	var tokens []string
+	for _, token := range context.Array() {
+		if token.String() == structs.CenterToken {
+			tokens = append(tokens, formatCenter(source))
+		} else if token.String() != structs.GapToken {
+			tokens = append(tokens, token.String())
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// formatCenter Функция возвращает центр окрестности в квадратных скобках: выражение или число
+func formatCenter(source gjson.Result) string {
+	if expression := source.Get("expression"); expression.Exists() {
+		return "[" + expression.String() + "]"
+	}
+
+	return "[" + strconv.FormatFloat(source.Get("num").Float(), 'f', -1, 64) + "]"
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/structs"
+	"github.com/tidwall/gjson"
+	"testing"
+)
+
+func TestParseNeighbourField(t *testing.T) {
+	tests := []struct {
+		field    string
+		expected int
+		ok       bool
+	}{
+		{"tb_1", -1, true},
+		{"ta_12", 12, true},
+		{"nb_1", 0, false},
+		{"tc_1", 0, false},
+		{"tb_x", 0, false},
+		{"tb_", 0, false},
+	}
+
+	for _, test := range tests {
+		if actual, ok := parseNeighbourField(test.field); actual != test.expected || ok != test.ok {
+			t.Errorf("parseNeighbourField(%q) = %d, %v, ожидалось %d, %v", test.field, actual, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestFindOffset(t *testing.T) {
+	setIndexFormat(t, structs.LayoutFlat, structs.StrategyTokens)
+
+	hit := gjson.Parse(`{"_source": {"num": 150}, "highlight": {"tb_3": ["<em>temperature</em>"], "ta_2": ["<em>temperature</em>"], "ta_4": ["<em>temperature</em>"]}}`)
+
+	tests := []struct {
+		direction string
+		expected  int
+	}{
+		{DirectionAny, 2},
+		{DirectionBefore, -3},
+		{DirectionAfter, 2},
+	}
+
+	for _, test := range tests {
+		if actual := findOffset(hit, []string{"temperature"}, 5, test.direction); actual != test.expected {
+			t.Errorf("findOffset(%s) = %d, ожидалось %d", test.direction, actual, test.expected)
+		}
+	}
+}
+
+func TestBuildContext(t *testing.T) {
+	setIndexFormat(t, structs.LayoutFlat, structs.StrategyTokens)
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{`{"num": 150, "tb_1": "to", "tb_2": "heated", "ta_1": "degrees"}`, "heated to [150] degrees"},
+		{`{"num": 1.5, "expression": "1.5-2.5", "ta_1": "mm"}`, "[1.5-2.5] mm"},
+		{`{"num": 5, "neighbours": [{"token": "mm", "offset": 1}, {"token": "at", "offset": -1}]}`, "at [5] mm"},
+		{`{"num": 5, "context": ["` + structs.GapToken + `", "at", "` + structs.CenterToken + `", "mm"]}`, "at [5] mm"},
+	}
+
+	for _, test := range tests {
+		if actual := buildContext(gjson.Parse(test.source), 5); actual != test.expected {
+			t.Errorf("buildContext(%s) = %q, ожидалось %q", test.source, actual, test.expected)
+		}
+	}
+}
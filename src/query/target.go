@@ -0,0 +1,82 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/structs"
+	"sort"
+)
+
+// Window Размерность окрестности, по индексам которой выполняется поиск: количество токенов (слов, символов - см. Strategy) слева и справа от числа
+type Window struct {
+	Left  int
+	Right int
+}
+
+// Ambit Функция возвращает наибольшее расстояние, доступное в окрестности с любой стороны от числа
+func (w Window) Ambit() int {
+	if w.Left > w.Right {
+		return w.Left
+	}
+
+	return w.Right
+}
+
+// Target Индексы окрестностей, по которым выполняется поиск.
+// Окрестности полей и языков с собственной размерностью (-FIELD_WINDOWS, -LANGUAGE_WINDOWS) записываются
+// в отдельные индексы, поэтому поиск идет и по ним
+type Target struct {
+	Prefix          string
+	Window          Window
+	FieldWindows    map[string]Window
+	LanguageWindows map[string]Window
+}
+
+// Ambit Функция возвращает наибольшее расстояние, которое допускается в запросе: наибольшее среди всех размерностей
+func (t Target) Ambit() int {
+	ambit := t.Window.Ambit()
+
+	for _, windows := range []map[string]Window{t.FieldWindows, t.LanguageWindows} {
+		for _, window := range windows {
+			if window.Ambit() > ambit {
+				ambit = window.Ambit()
+			}
+		}
+	}
+
+	return ambit
+}
+
+// IndexNames Функция возвращает имена индексов окрестностей языка: индекс размерности поиска, индексы размерностей полей
+// и индекс размерности языка. Вместо языка допускается передавать "*", тогда учитываются размерности всех языков
+func (t Target) IndexNames(language string) []string {
+	windows := []Window{t.Window}
+	for _, window := range t.FieldWindows {
+		windows = append(windows, window)
+	}
+
+	var names []string
+	add := func(language string, window Window) {
+		name := elastic.GetProximityWindowIndexName(t.Prefix, language, structs.StrategyIndexInfix(Strategy), window.Left, window.Right)
+		for _, existing := range names {
+			if existing == name {
+				return
+			}
+		}
+
+		names = append(names, name)
+	}
+
+	for _, window := range windows {
+		add(language, window)
+	}
+
+	for windowLanguage, window := range t.LanguageWindows {
+		if language == "*" || language == windowLanguage {
+			add(windowLanguage, window)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
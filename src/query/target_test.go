@@ -0,0 +1,58 @@
+package query
+
+import (
+	"elastic-proximity-calculation/src/elastic"
+	"elastic-proximity-calculation/src/structs"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTargetAmbit(t *testing.T) {
+	tests := []struct {
+		target   Target
+		expected int
+	}{
+		{Target{Window: Window{5, 5}}, 5},
+		{Target{Window: Window{3, 15}}, 15},
+		{Target{Window: Window{5, 5}, FieldWindows: map[string]Window{"title": {20, 2}}}, 20},
+		{Target{Window: Window{5, 5}, LanguageWindows: map[string]Window{"ru": {2, 30}}}, 30},
+		{Target{Window: Window{5, 5}, FieldWindows: map[string]Window{"title": {2, 2}}}, 5},
+	}
+
+	for _, test := range tests {
+		if actual := test.target.Ambit(); actual != test.expected {
+			t.Errorf("%+v.Ambit() = %d, ожидалось %d", test.target, actual, test.expected)
+		}
+	}
+}
+
+func TestTargetIndexNames(t *testing.T) {
+	target := Target{
+		Prefix:          "p_",
+		Window:          Window{5, 5},
+		FieldWindows:    map[string]Window{"title": {2, 3}, "body": {5, 5}},
+		LanguageWindows: map[string]Window{"ru": {10, 10}, "de": {4, 4}},
+	}
+
+	name := func(language string, left int, right int) string {
+		return elastic.GetProximityWindowIndexName("p_", language, structs.StrategyIndexInfix(Strategy), left, right)
+	}
+
+	tests := []struct {
+		language string
+		expected []string
+	}{
+		{"en", []string{name("en", 5, 5), name("en", 2, 3)}},
+		{"ru", []string{name("ru", 5, 5), name("ru", 2, 3), name("ru", 10, 10)}},
+		{"*", []string{name("*", 5, 5), name("*", 2, 3), name("ru", 10, 10), name("de", 4, 4)}},
+	}
+
+	for _, test := range tests {
+		sort.Strings(test.expected)
+
+		if actual := target.IndexNames(test.language); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("IndexNames(%q) = %v, ожидалось %v", test.language, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package server
+
+import "elastic-proximity-calculation/src/query"
+
+type Config struct {
+	Address string
+	Target  query.Target
+}
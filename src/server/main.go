@@ -0,0 +1,145 @@
+package server
+
+import (
+	"elastic-proximity-calculation/src/logger"
+	"elastic-proximity-calculation/src/query"
+	"encoding/json"
+	"github.com/elastic/go-elasticsearch/v7"
+	"net/http"
+	"strconv"
+)
+
+var (
+	client *elasticsearch.Client
+
+	config Config
+)
+
+// searchRequest Тело запроса POST /search
+type searchRequest struct {
+	Query     string   `json:"query"`
+	Word      string   `json:"word"`
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	Unit      string   `json:"unit"`
+	Distance  int      `json:"distance"`
+	Direction string   `json:"direction"`
+	Language  string   `json:"language"`
+	Size      int      `json:"size"`
+}
+
+// searchGroup Найденные окрестности одного поля одного исходного документа
+type searchGroup struct {
+	SourceIndex string      `json:"source_index"`
+	SourceId    string      `json:"source_id"`
+	SourceField string      `json:"source_field"`
+	Hits        []query.Hit `json:"hits"`
+}
+
+type searchResponse struct {
+	Total  int            `json:"total"`
+	Groups []*searchGroup `json:"groups"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Serve Функция запускает HTTP-сервис поиска по индексам окрестностей.
+// Блокирует выполнение до остановки сервера
+func Serve(initClient *elasticsearch.Client, initConfig Config) error {
+	client = initClient
+	config = initConfig
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", handleSearch)
+
+	logger.Info("HTTP-сервис слушает адрес %s", config.Address)
+
+	return http.ListenAndServe(config.Address, mux)
+}
+
+func handleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "допустим только метод POST"})
+		return
+	}
+
+	body := searchRequest{
+		Distance:  config.Target.Ambit(),
+		Direction: query.DirectionAny,
+		Size:      10,
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "некорректное тело запроса: " + err.Error()})
+		return
+	}
+
+	request := query.Request{
+		Expression: body.Query,
+		Word:       body.Word,
+		Min:        body.Min,
+		Max:        body.Max,
+		Unit:       body.Unit,
+		Distance:   body.Distance,
+		Direction:  body.Direction,
+		Language:   body.Language,
+		Size:       body.Size,
+	}
+
+	if err := request.Validate(config.Target.Ambit()); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	hits, err := query.Search(client, config.Target, request)
+	if err != nil {
+		logger.Warning("Ошибка при выполнении поиска: %s", err.Error())
+		writeJSON(w, http.StatusBadGateway, errorResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, searchResponse{
+		Total:  len(hits),
+		Groups: groupHits(hits),
+	})
+}
+
+// groupHits Функция группирует найденные окрестности по исходному документу и полю, сохраняя порядок выдачи
+func groupHits(hits []query.Hit) []*searchGroup {
+	groups := []*searchGroup{}
+	index := map[string]*searchGroup{}
+
+	for _, hit := range hits {
+		key := hit.SourceIndex + "/" + hit.SourceId + "/" + hit.SourceField
+
+		group, ok := index[key]
+		if !ok {
+			group = &searchGroup{
+				SourceIndex: hit.SourceIndex,
+				SourceId:    hit.SourceId,
+				SourceField: hit.SourceField,
+			}
+			index[key] = group
+			groups = append(groups, group)
+		}
+
+		group.Hits = append(group.Hits, hit)
+	}
+
+	return groups
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(`{"error":"ошибка кодирования JSON"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	w.Write(body)
+}
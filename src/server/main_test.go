@@ -0,0 +1,156 @@
+package server
+
+import (
+	"elastic-proximity-calculation/src/query"
+	"encoding/json"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/tidwall/gjson"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useTestClient Функция на время теста подменяет клиент и конфигурацию сервиса: запросы к Elasticsearch обрабатывает handler.
+// Запрос информации о кластере, которым клиент проверяет продукт, обрабатывается здесь же
+func useTestClient(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/" {
+			w.Write([]byte(`{"version": {"number": "7.17.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`))
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	previousClient, previousConfig := client, config
+	t.Cleanup(func() { client, config = previousClient, previousConfig })
+
+	var err error
+	client, err = elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("elasticsearch.NewClient() = %v", err)
+	}
+
+	config = Config{Target: query.Target{Prefix: "p_", Window: query.Window{Left: 5, Right: 5}}}
+}
+
+// search Функция выполняет запрос к handleSearch и возвращает код ответа и тело
+func search(method string, body string) (int, string) {
+	recorder := httptest.NewRecorder()
+	handleSearch(recorder, httptest.NewRequest(method, "/search", strings.NewReader(body)))
+
+	return recorder.Code, recorder.Body.String()
+}
+
+func TestHandleSearchErrors(t *testing.T) {
+	requested := false
+	useTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Write([]byte(`{"hits": {"hits": []}}`))
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{"метод GET", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"некорректный JSON", http.MethodPost, `{"word": "temperature"`, http.StatusBadRequest},
+		{"неверный тип поля", http.MethodPost, `{"word": "temperature", "distance": "5"}`, http.StatusBadRequest},
+		{"некорректное выражение", http.MethodPost, `{"query": "temperature NEAR/5"}`, http.StatusBadRequest},
+		{"расстояние в выражении больше окрестности", http.MethodPost, `{"query": "temperature NEAR/9 [100..200]"}`, http.StatusBadRequest},
+		{"нет слова", http.MethodPost, `{"min": 1}`, http.StatusBadRequest},
+		{"неизвестная единица", http.MethodPost, `{"word": "temperature", "unit": "parsec-per-hour"}`, http.StatusBadRequest},
+		{"неизвестное направление", http.MethodPost, `{"word": "temperature", "direction": "above"}`, http.StatusBadRequest},
+		{"нулевой размер выдачи", http.MethodPost, `{"word": "temperature", "size": 0}`, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		status, body := search(test.method, test.body)
+		if status != test.status || gjson.Get(body, "error").String() == "" {
+			t.Errorf("handleSearch(%s) = %d %s, ожидался код %d и текст ошибки", test.name, status, body, test.status)
+		}
+	}
+
+	if requested {
+		t.Errorf("handleSearch() обратился к Elasticsearch при некорректном запросе")
+	}
+}
+
+func TestHandleSearch(t *testing.T) {
+	var indices string
+	var esBody []byte
+	useTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		indices = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/_search")
+		esBody, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"hits": {"hits": [
+			{"_index": "p_en_proximity_5_5", "_score": 2, "_source": {"source_index": "docs", "source_id": "1", "source_field": "text", "num": 150}},
+			{"_index": "p_en_proximity_5_5", "_score": 1, "_source": {"source_index": "docs", "source_id": "1", "source_field": "text", "num": 160}},
+			{"_index": "p_en_proximity_5_5", "_score": 1, "_source": {"source_index": "docs", "source_id": "2", "source_field": "text", "num": 170}}
+		]}}`))
+	})
+
+	status, body := search(http.MethodPost, `{"query": "temperature NEAR/5 [100..200]", "language": "en"}`)
+	if status != http.StatusOK {
+		t.Fatalf("handleSearch() = %d %s", status, body)
+	}
+
+	if indices != strings.Join(config.Target.IndexNames("en"), ",") {
+		t.Errorf("handleSearch() индексы поиска = %q, ожидалось %v", indices, config.Target.IndexNames("en"))
+	}
+
+	if !json.Valid(esBody) || !gjson.GetBytes(esBody, "query").Exists() {
+		t.Errorf("handleSearch() тело запроса к Elasticsearch = %s", esBody)
+	}
+
+	var response searchResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("handleSearch() ответ %s: %v", body, err)
+	}
+
+	if response.Total != 3 || len(response.Groups) != 2 || len(response.Groups[0].Hits) != 2 || response.Groups[1].SourceId != "2" {
+		t.Errorf("handleSearch() = %s", body)
+	}
+}
+
+func TestHandleSearchElasticError(t *testing.T) {
+	useTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": "internal"}`))
+	})
+
+	if status, body := search(http.MethodPost, `{"word": "temperature"}`); status != http.StatusBadGateway {
+		t.Errorf("handleSearch() = %d %s, ожидался код %d", status, body, http.StatusBadGateway)
+	}
+}
+
+func TestGroupHits(t *testing.T) {
+	hits := []query.Hit{
+		{SourceIndex: "docs", SourceId: "2", SourceField: "text", Num: 1},
+		{SourceIndex: "docs", SourceId: "1", SourceField: "text", Num: 2},
+		{SourceIndex: "docs", SourceId: "2", SourceField: "text", Num: 3},
+		{SourceIndex: "docs", SourceId: "2", SourceField: "title", Num: 4},
+	}
+
+	var actual [][]float64
+	for _, group := range groupHits(hits) {
+		var nums []float64
+		for _, hit := range group.Hits {
+			nums = append(nums, hit.Num)
+		}
+		actual = append(actual, nums)
+	}
+
+	expected := [][]float64{{1, 3}, {2}, {4}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("groupHits() = %v, ожидалось %v", actual, expected)
+	}
+}
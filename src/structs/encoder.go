@@ -0,0 +1,94 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Encoder Кодирование окрестности в документ индекса. Формат документа определяется только кодировщиком,
+// поэтому окрестности записываются в индекс исключительно через Encoder
+type Encoder interface {
+	Encode(p *Proximity) ([]byte, error)
+	// Mappings Маппинг индекса окрестностей: поля документа (см. DocumentProperties) и поля соседей.
+	// Анализатор применяется к текстовым полям соседей, пустая строка означает анализатор по умолчанию
+	Mappings(analyzer string) map[string]interface{}
+	// Settings Настройки индекса окрестностей (анализаторы), необходимые для маппинга. Может быть nil
+	Settings() map[string]interface{}
+}
+
+// FlatEncoder Плоский формат: соседи слева - поля tb_N (текст), nb_N (число), db_N (расстояние в символах), соседи справа - ta_N, na_N, da_N
+type FlatEncoder struct{}
+
+func (FlatEncoder) Encode(p *Proximity) ([]byte, error) {
+	buffer, err := openDocument(p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, side := range []struct {
+		suffix     string
+		neighbours []Neighbour
+	}{{"b", p.Before}, {"a", p.After}} {
+		for _, n := range side.neighbours {
+			needleIndex := strconv.Itoa(n.Position)
+
+			if err := writeField(buffer, "t"+side.suffix+"_"+needleIndex, n.Text); err != nil {
+				return nil, err
+			}
+
+			if n.Num != nil {
+				if err := writeField(buffer, "n"+side.suffix+"_"+needleIndex, *n.Num); err != nil {
+					return nil, err
+				}
+			}
+
+			if n.Distance != nil {
+				if err := writeField(buffer, "d"+side.suffix+"_"+needleIndex, *n.Distance); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// openDocument Функция кодирует общие поля окрестности и возвращает JSON-объект без закрывающей скобки,
+// чтобы кодировщик дописал поля соседей (см. writeField). У якорей поле numbers записывается всегда, в том числе пустым
+func openDocument(p *Proximity) (*bytes.Buffer, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(data[:len(data)-1])
+
+	if p.IsAnchor() {
+		numbers := p.Numbers
+		if numbers == nil {
+			numbers = []Number{}
+		}
+
+		if err := writeField(buffer, "numbers", numbers); err != nil {
+			return nil, err
+		}
+	}
+
+	return buffer, nil
+}
+
+// writeField Функция дописывает поле в конец кодируемого JSON-объекта
+func writeField(buffer *bytes.Buffer, name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	buffer.WriteString(`,"` + name + `":`)
+	buffer.Write(data)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// encode Функция кодирует окрестность и разбирает полученный документ
+func encode(t *testing.T, encoder Encoder, p Proximity) map[string]interface{} {
+	t.Helper()
+
+	data, err := encoder.Encode(&p)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Encode вернул некорректный JSON %s: %v", data, err)
+	}
+
+	return document
+}
+
+func intPointer(v int) *int {
+	return &v
+}
+
+func floatPointer(v float64) *float64 {
+	return &v
+}
+
+func TestFlatEncoder(t *testing.T) {
+	p := CreateProximityObject("docs", "1", "text", 5)
+	p.Before = []Neighbour{{Position: 1, Text: "gap"}, {Position: 2, Text: "7", Num: floatPointer(7)}}
+	p.After = []Neighbour{{Position: 1, Text: "mm"}}
+
+	document := encode(t, FlatEncoder{}, p)
+
+	expected := map[string]interface{}{
+		"tb_1": "gap", "tb_2": "7", "nb_2": 7.0, "ta_1": "mm",
+		"num": 5.0, "schema_version": 1.0, "source_id": "1",
+	}
+	for name, value := range expected {
+		if !reflect.DeepEqual(document[name], value) {
+			t.Errorf("поле %s = %v, ожидалось %v", name, document[name], value)
+		}
+	}
+
+	for _, name := range []string{"nb_1", "na_1", "db_1", "numbers"} {
+		if _, ok := document[name]; ok {
+			t.Errorf("поле %s не должно записываться: %v", name, document[name])
+		}
+	}
+}
+
+func TestFlatEncoderDistances(t *testing.T) {
+	p := CreateProximityObject("docs", "1", "text", 5)
+	p.WindowStrategy = StrategyChars
+	p.After = []Neighbour{{Position: 1, Text: "mm", Distance: intPointer(1)}, {Position: 2, Text: "long", Distance: intPointer(4)}}
+
+	document := encode(t, FlatEncoder{}, p)
+
+	if document["da_1"] != 1.0 || document["da_2"] != 4.0 || document["ta_2"] != "long" {
+		t.Errorf("Encode = %v, ожидались da_1 = 1, da_2 = 4, ta_2 = long", document)
+	}
+}
+
+func TestEncoderAnchorNumbers(t *testing.T) {
+	withoutNumbers := CreateAnchorProximityObject("docs", "1", "text", "steel", "term", "steel")
+
+	withNumbers := withoutNumbers
+	withNumbers.Numbers = []Number{{Value: Value{Num: floatPointer(5)}, Distance: -2}}
+
+	for _, layout := range Layouts {
+		encoder, _ := GetEncoder(layout)
+
+		numbers, ok := encode(t, encoder, withoutNumbers)["numbers"]
+		if !ok || !reflect.DeepEqual(numbers, []interface{}{}) {
+			t.Errorf("%s: numbers якоря без чисел = %v, ожидался пустой список", layout, numbers)
+		}
+
+		expected := []interface{}{map[string]interface{}{"num": 5.0, "distance": -2.0}}
+		if numbers := encode(t, encoder, withNumbers)["numbers"]; !reflect.DeepEqual(numbers, expected) {
+			t.Errorf("%s: numbers = %v, ожидалось %v", layout, numbers, expected)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package structs
+
+import "strconv"
+
+const (
+	// LayoutFlat Соседи в отдельных полях tb_N/ta_N, nb_N/na_N (см. FlatEncoder)
+	LayoutFlat = "flat"
+	// LayoutNested Соседи в nested-массиве neighbours (см. NestedEncoder)
+	LayoutNested = "nested"
+	// LayoutPositions Соседи в текстовом поле context, разница позиций токена и центра равна расстоянию до центра (см. PositionsEncoder)
+	LayoutPositions = "positions"
+)
+
+// Layouts Допустимые форматы записи соседей
+var Layouts = []string{LayoutFlat, LayoutNested, LayoutPositions}
+
+// CenterToken Токен, которым в поле context формата LayoutPositions обозначается центр окрестности
+const CenterToken = "__center__"
+
+// GapToken Токен, которым в поле context формата LayoutPositions заполняются позиции без соседа.
+// Анализатор поля отбрасывает его, сохраняя позицию, поэтому пропуски не находятся запросами
+const GapToken = "__gap__"
+
+// GetEncoder Функция возвращает кодировщик для формата записи соседей
+func GetEncoder(layout string) (Encoder, bool) {
+	switch layout {
+	case LayoutFlat:
+		return FlatEncoder{}, true
+	case LayoutNested:
+		return NestedEncoder{}, true
+	case LayoutPositions:
+		return PositionsEncoder{}, true
+	}
+
+	return nil, false
+}
+
+// NestedNeighbour Сосед в формате LayoutNested. Смещение отрицательное для соседей слева
+type NestedNeighbour struct {
+	Token    string   `json:"token"`
+	Number   *float64 `json:"number,omitempty"`
+	Offset   int      `json:"offset"`
+	Side     string   `json:"side"`
+	Distance *int     `json:"distance,omitempty"`
+}
+
+const (
+	SideBefore = "before"
+	SideAfter  = "after"
+)
+
+// NestedEncoder Формат с соседями в nested-массиве neighbours из объектов {token, number, offset, side}.
+// Количество полей в индексе не зависит от размерности окрестности
+type NestedEncoder struct{}
+
+func (NestedEncoder) Encode(p *Proximity) ([]byte, error) {
+	buffer, err := openDocument(p)
+	if err != nil {
+		return nil, err
+	}
+
+	neighbours := make([]NestedNeighbour, 0, len(p.Before)+len(p.After))
+	for _, n := range p.Before {
+		neighbours = append(neighbours, NestedNeighbour{Token: n.Text, Number: n.Num, Offset: -n.Position, Side: SideBefore, Distance: n.Distance})
+	}
+	for _, n := range p.After {
+		neighbours = append(neighbours, NestedNeighbour{Token: n.Text, Number: n.Num, Offset: n.Position, Side: SideAfter, Distance: n.Distance})
+	}
+
+	if err := writeField(buffer, "neighbours", neighbours); err != nil {
+		return nil, err
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+func (NestedEncoder) Mappings(analyzer string) map[string]interface{} {
+	properties := DocumentProperties()
+	properties["neighbours"] = map[string]interface{}{
+		"type": "nested",
+		"properties": map[string]interface{}{
+			"token":    textWithKeyword(analyzer),
+			"number":   map[string]interface{}{"type": "double"},
+			"offset":   map[string]interface{}{"type": "integer"},
+			"side":     map[string]interface{}{"type": "keyword"},
+			"distance": map[string]interface{}{"type": "integer"},
+		},
+	}
+
+	return map[string]interface{}{
+		"properties": properties,
+	}
+}
+
+func (NestedEncoder) Settings() map[string]interface{} {
+	return nil
+}
+
+// PositionsEncoder Формат с соседями в одном текстовом поле context: массив из токенов слева, CenterToken и токенов справа.
+// Каждый элемент массива занимает ровно одну позицию (анализатор keyword, position_increment_gap = 0),
+// поэтому разница позиций токена и CenterToken равна смещению соседа (см. PositionOffset), и условие "слово не дальше k от числа"
+// выражается через span_near или match_phrase со slop = k - 1. Позиции без соседа заполняются GapToken.
+// Числовые значения соседей записываются в поля nb_N/na_N, как в FlatEncoder
+type PositionsEncoder struct{}
+
+func (PositionsEncoder) Encode(p *Proximity) ([]byte, error) {
+	buffer, err := openDocument(p)
+	if err != nil {
+		return nil, err
+	}
+
+	before, after := positionsSide(p.Before), positionsSide(p.After)
+
+	context := make([]string, 0, len(before)+len(after)+1)
+	for i := len(before) - 1; i >= 0; i-- {
+		context = append(context, before[i])
+	}
+	context = append(context, CenterToken)
+	context = append(context, after...)
+
+	if err := writeField(buffer, "context", context); err != nil {
+		return nil, err
+	}
+
+	for _, side := range []struct {
+		suffix     string
+		neighbours []Neighbour
+	}{{"b", p.Before}, {"a", p.After}} {
+		for _, n := range side.neighbours {
+			if n.Num == nil {
+				continue
+			}
+
+			if err := writeField(buffer, "n"+side.suffix+"_"+strconv.Itoa(n.Position), *n.Num); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// PositionOffset Функция возвращает смещение соседа от центра в поле context: номер соседа,
+// а при подсчете размерности в символах - расстояние в символах плюс один, чтобы соседи не совпадали с центром
+func PositionOffset(n Neighbour) int {
+	if n.Distance != nil {
+		return *n.Distance + 1
+	}
+
+	return n.Position
+}
+
+// positionsSide Функция возвращает токены соседей с одной стороны от центра по возрастанию смещения.
+// Элемент с индексом i соответствует смещению i + 1, позиции без соседа заполняются GapToken
+func positionsSide(neighbours []Neighbour) []string {
+	var side []string
+
+	for _, n := range neighbours {
+		offset := PositionOffset(n)
+		for len(side) < offset {
+			side = append(side, GapToken)
+		}
+
+		side[offset-1] = n.Text
+	}
+
+	return side
+}
+
+// Mappings Анализатор языка к полю context не применяется: каждый сосед должен оставаться одним токеном
+func (PositionsEncoder) Mappings(analyzer string) map[string]interface{} {
+	properties := DocumentProperties()
+	properties["context"] = map[string]interface{}{
+		"type":                   "text",
+		"analyzer":               "proximity_position",
+		"search_analyzer":        "proximity_position_search",
+		"position_increment_gap": 0,
+		"term_vector":            "with_positions_offsets",
+	}
+
+	return map[string]interface{}{
+		"properties": properties,
+		"dynamic_templates": []interface{}{
+			dynamicTemplate("neighbour_numbers", "n?_*", map[string]interface{}{"type": "double"}),
+		},
+	}
+}
+
+func (PositionsEncoder) Settings() map[string]interface{} {
+	return map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				"proximity_position": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "keyword",
+					"filter":    []string{"lowercase", "proximity_gap"},
+				},
+				"proximity_position_search": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "whitespace",
+					"filter":    []string{"lowercase"},
+				},
+			},
+			"filter": map[string]interface{}{
+				"proximity_gap": map[string]interface{}{
+					"type":      "stop",
+					"stopwords": []string{GapToken},
+				},
+			},
+		},
+	}
+}
+
+func (FlatEncoder) Mappings(analyzer string) map[string]interface{} {
+	return map[string]interface{}{
+		"properties": DocumentProperties(),
+		"dynamic_templates": []interface{}{
+			dynamicTemplate("neighbour_tokens", "t?_*", textWithKeyword(analyzer)),
+			dynamicTemplate("neighbour_numbers", "n?_*", map[string]interface{}{"type": "double"}),
+			dynamicTemplate("neighbour_distances", "d?_*", map[string]interface{}{"type": "integer"}),
+		},
+	}
+}
+
+func (FlatEncoder) Settings() map[string]interface{} {
+	return nil
+}
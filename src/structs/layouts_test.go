@@ -0,0 +1,95 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNestedEncoder(t *testing.T) {
+	p := CreateProximityObject("docs", "1", "text", 5)
+	p.Before = []Neighbour{{Position: 1, Text: "at"}, {Position: 2, Text: "3", Num: floatPointer(3)}}
+	p.After = []Neighbour{{Position: 1, Text: "mm", Distance: intPointer(1)}}
+
+	expected := []interface{}{
+		map[string]interface{}{"token": "at", "offset": -1.0, "side": SideBefore},
+		map[string]interface{}{"token": "3", "number": 3.0, "offset": -2.0, "side": SideBefore},
+		map[string]interface{}{"token": "mm", "offset": 1.0, "side": SideAfter, "distance": 1.0},
+	}
+
+	document := encode(t, NestedEncoder{}, p)
+	if !reflect.DeepEqual(document["neighbours"], expected) {
+		t.Errorf("neighbours = %v, ожидалось %v", document["neighbours"], expected)
+	}
+
+	for _, name := range []string{"tb_1", "ta_1", "nb_2"} {
+		if _, ok := document[name]; ok {
+			t.Errorf("поле %s не должно записываться в формате %s", name, LayoutNested)
+		}
+	}
+}
+
+func TestNestedEncoderEmpty(t *testing.T) {
+	document := encode(t, NestedEncoder{}, CreateProximityObject("docs", "1", "text", 5))
+
+	if !reflect.DeepEqual(document["neighbours"], []interface{}{}) {
+		t.Errorf("neighbours = %v, ожидался пустой список", document["neighbours"])
+	}
+}
+
+func TestPositionsEncoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  []Neighbour
+		after   []Neighbour
+		context []interface{}
+		numbers map[string]interface{}
+	}{
+		{
+			name:    "токены",
+			before:  []Neighbour{{Position: 1, Text: "at"}, {Position: 2, Text: "3", Num: floatPointer(3)}},
+			after:   []Neighbour{{Position: 1, Text: "mm"}},
+			context: []interface{}{"3", "at", CenterToken, "mm"},
+			numbers: map[string]interface{}{"nb_2": 3.0},
+		},
+		{
+			name:    "символы",
+			before:  []Neighbour{{Position: 1, Text: "at", Distance: intPointer(1)}},
+			after:   []Neighbour{{Position: 1, Text: "mm", Distance: intPointer(0)}, {Position: 2, Text: "7", Num: floatPointer(7), Distance: intPointer(3)}},
+			context: []interface{}{"at", GapToken, CenterToken, "mm", GapToken, GapToken, "7"},
+			numbers: map[string]interface{}{"na_2": 7.0},
+		},
+	}
+
+	for _, test := range tests {
+		p := CreateProximityObject("docs", "1", "text", 5)
+		p.Before, p.After = test.before, test.after
+
+		document := encode(t, PositionsEncoder{}, p)
+		if !reflect.DeepEqual(document["context"], test.context) {
+			t.Errorf("%s: context = %v, ожидалось %v", test.name, document["context"], test.context)
+		}
+
+		for name, value := range test.numbers {
+			if document[name] != value {
+				t.Errorf("%s: поле %s = %v, ожидалось %v", test.name, name, document[name], value)
+			}
+		}
+	}
+}
+
+func TestPositionOffset(t *testing.T) {
+	tests := []struct {
+		neighbour Neighbour
+		offset    int
+	}{
+		{Neighbour{Position: 3}, 3},
+		{Neighbour{Position: 1, Distance: intPointer(0)}, 1},
+		{Neighbour{Position: 2, Distance: intPointer(5)}, 6},
+	}
+
+	for _, test := range tests {
+		if offset := PositionOffset(test.neighbour); offset != test.offset {
+			t.Errorf("PositionOffset(%+v) = %d, ожидалось %d", test.neighbour, offset, test.offset)
+		}
+	}
+}
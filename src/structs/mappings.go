@@ -0,0 +1,80 @@
+package structs
+
+// MappingVersion Версия маппинга индекса окрестностей. Увеличивается при любом изменении маппинга или формата документа
+const MappingVersion = 2
+
+// DocumentProperties Функция возвращает маппинг полей документа окрестности, общих для всех форматов записи соседей
+func DocumentProperties() map[string]interface{} {
+	keyword := map[string]interface{}{"type": "keyword"}
+	double := map[string]interface{}{"type": "double"}
+	integer := map[string]interface{}{"type": "integer"}
+
+	value := map[string]interface{}{
+		"num":             double,
+		"num_min":         double,
+		"num_max":         double,
+		"expression":      textWithKeyword(""),
+		"expression_type": keyword,
+		"unit":            keyword,
+		"unit_category":   keyword,
+		"num_si":          double,
+		"num_min_si":      double,
+		"num_max_si":      double,
+	}
+
+	numbers := map[string]interface{}{
+		"distance": integer,
+	}
+	for name, mapping := range value {
+		numbers[name] = mapping
+	}
+
+	properties := map[string]interface{}{
+		"schema_version":     integer,
+		"source_index":       keyword,
+		"source_id":          keyword,
+		"source_field":       keyword,
+		"spelled_out":        map[string]interface{}{"type": "boolean"},
+		"literal":            keyword,
+		"significant_digits": integer,
+		"num_implied_min":    double,
+		"num_implied_max":    double,
+		"noise":              keyword,
+		"anchor":             textWithKeyword(""),
+		"anchor_type":        keyword,
+		"date":               map[string]interface{}{"type": "date", "format": "yyyy-MM-dd||yyyy-MM||yyyy"},
+		"numbers":            map[string]interface{}{"properties": numbers},
+		"window_left":        integer,
+		"window_right":       integer,
+		"window_strategy":    keyword,
+	}
+	for name, mapping := range value {
+		properties[name] = mapping
+	}
+
+	return properties
+}
+
+func dynamicTemplate(name string, pattern string, mapping map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		name: map[string]interface{}{
+			"match":   pattern,
+			"mapping": mapping,
+		},
+	}
+}
+
+// textWithKeyword Функция возвращает маппинг текстового поля с подполем keyword. Пустой анализатор означает анализатор по умолчанию
+func textWithKeyword(analyzer string) map[string]interface{} {
+	mapping := map[string]interface{}{
+		"type": "text",
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+		},
+	}
+	if analyzer != "" {
+		mapping["analyzer"] = analyzer
+	}
+
+	return mapping
+}
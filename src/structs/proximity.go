@@ -2,27 +2,126 @@ package structs
 
 import "sync"
 
-type Proximity map[string]interface{}
+// SchemaVersion Версия формата документа окрестности в индексе. Увеличивается при любом несовместимом изменении формата
+const SchemaVersion = 1
+
+// Value Числовое значение: одиночное число (num), диапазон или допуск (num_min..num_max) с единицей измерения
+type Value struct {
+	Num            *float64 `json:"num,omitempty"`
+	NumMin         *float64 `json:"num_min,omitempty"`
+	NumMax         *float64 `json:"num_max,omitempty"`
+	Expression     string   `json:"expression,omitempty"`
+	ExpressionType string   `json:"expression_type,omitempty"`
+	Unit           string   `json:"unit,omitempty"`
+	UnitCategory   string   `json:"unit_category,omitempty"`
+	NumSI          *float64 `json:"num_si,omitempty"`
+	NumMinSI       *float64 `json:"num_min_si,omitempty"`
+	NumMaxSI       *float64 `json:"num_max_si,omitempty"`
+}
+
+// Number Число рядом с якорем и расстояние до него со знаком (отрицательное - число слева)
+type Number struct {
+	Value
+	Distance int `json:"distance"`
+}
+
+// Neighbour Сосед центра окрестности
+type Neighbour struct {
+	// Position Номер соседа: расстояние в токенах или словах, при подсчете в символах - порядковый номер
+	Position int
+	Text     string
+	// Num Значение соседа, если сосед - одиночное число
+	Num *float64
+	// Distance Расстояние в символах, заполняется только при подсчете размерности в символах
+	Distance *int
+}
+
+// Proximity Окрестность числа или якоря. Соседи хранятся в Before/After и записываются в индекс через Encoder
+type Proximity struct {
+	SchemaVersion int    `json:"schema_version"`
+	SourceIndex   string `json:"source_index"`
+	SourceId      string `json:"source_id"`
+	SourceField   string `json:"source_field"`
+
+	Value
+	SpelledOut        bool     `json:"spelled_out,omitempty"`
+	Literal           string   `json:"literal,omitempty"`
+	SignificantDigits int      `json:"significant_digits,omitempty"`
+	NumImpliedMin     *float64 `json:"num_implied_min,omitempty"`
+	NumImpliedMax     *float64 `json:"num_implied_max,omitempty"`
+	Noise             string   `json:"noise,omitempty"`
+
+	Anchor     string `json:"anchor,omitempty"`
+	AnchorType string `json:"anchor_type,omitempty"`
+	Date       string `json:"date,omitempty"`
+	// Numbers Числа вокруг якоря. Записываются кодировщиком только для якорей, всегда - даже пустым списком
+	Numbers []Number `json:"-"`
+
+	WindowLeft     int    `json:"window_left"`
+	WindowRight    int    `json:"window_right"`
+	WindowStrategy string `json:"window_strategy,omitempty"`
+
+	Before []Neighbour `json:"-"`
+	After  []Neighbour `json:"-"`
+}
 
 func CreateProximityObject(sourceIndex string, sourceId string, sourceField string, num float64) Proximity {
 	return Proximity{
-		"source_index": sourceIndex,
-		"source_id":    sourceId,
-		"source_field": sourceField,
-		"num":          num,
+		SchemaVersion: SchemaVersion,
+		SourceIndex:   sourceIndex,
+		SourceId:      sourceId,
+		SourceField:   sourceField,
+		Value:         Value{Num: &num},
+	}
+}
+
+// CreateRangeProximityObject Функция создает окрестность для диапазона (10-20) или допуска (5±0.2).
+// Вместо одного числа хранятся границы и исходная запись выражения
+func CreateRangeProximityObject(sourceIndex string, sourceId string, sourceField string, numMin float64, numMax float64, expression string, expressionType string) Proximity {
+	return Proximity{
+		SchemaVersion: SchemaVersion,
+		SourceIndex:   sourceIndex,
+		SourceId:      sourceId,
+		SourceField:   sourceField,
+		Value: Value{
+			NumMin:         &numMin,
+			NumMax:         &numMax,
+			Expression:     expression,
+			ExpressionType: expressionType,
+		},
 	}
 }
 
+// CreateAnchorProximityObject Функция создает окрестность якоря - не числа (термина словаря, именованного выражения, даты).
+// anchor - нормализованное значение якоря, expression - его запись в тексте
+func CreateAnchorProximityObject(sourceIndex string, sourceId string, sourceField string, anchor string, anchorType string, expression string) Proximity {
+	return Proximity{
+		SchemaVersion: SchemaVersion,
+		SourceIndex:   sourceIndex,
+		SourceId:      sourceId,
+		SourceField:   sourceField,
+		Value:         Value{Expression: expression},
+		Anchor:        anchor,
+		AnchorType:    anchorType,
+	}
+}
+
+// IsAnchor Функция проверяет, что центр окрестности - якорь, а не число
+func (p *Proximity) IsAnchor() bool {
+	return p.AnchorType != ""
+}
+
 type Container struct {
 	mx sync.RWMutex
 	m  map[string][]*Proximity
 }
 
-func (c *Container) Add(language string, proximity *Proximity) {
+// Add Функция добавляет окрестность в буфер индекса окрестностей
+func (c *Container) Add(index string, proximity *Proximity) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
 
-	c.m[language] = append(c.m[language], proximity)
+	c.m[index] = append(c.m[index], proximity)
 }
 
 func (c *Container) CheckTotalLength(uploadChunkSize int) bool {
@@ -37,13 +136,6 @@ func (c *Container) CheckTotalLength(uploadChunkSize int) bool {
 	return total >= uploadChunkSize
 }
 
-func (c *Container) GetByLanguage(language string) []*Proximity {
-	c.mx.RLock()
-	defer c.mx.RUnlock()
-
-	return c.m[language]
-}
-
 func (c *Container) GetAll() map[string][]*Proximity {
 	c.mx.RLock()
 	defer c.mx.RUnlock()
@@ -51,11 +143,11 @@ func (c *Container) GetAll() map[string][]*Proximity {
 	return c.m
 }
 
-func (c *Container) DeleteByLanguage(language string) {
+func (c *Container) DeleteByIndex(index string) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
 
-	delete(c.m, language)
+	delete(c.m, index)
 }
 
 func NewContainer() *Container {
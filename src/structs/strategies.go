@@ -0,0 +1,33 @@
+package structs
+
+const (
+	StrategyTokens = "tokens"
+	StrategyWords  = "words"
+	StrategyChars  = "chars"
+)
+
+// WindowStrategies Допустимые способы подсчета расстояния от числа до соседа:
+// tokens - все токены, words - только слова (числа пропускаются), chars - символы между числом и соседом
+var WindowStrategies = []string{StrategyTokens, StrategyWords, StrategyChars}
+
+// IsWindowStrategy Функция проверяет, что способ подсчета расстояния существует
+func IsWindowStrategy(strategy string) bool {
+	for _, windowStrategy := range WindowStrategies {
+		if windowStrategy == strategy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StrategyIndexInfix Функция возвращает способ подсчета расстояния для имени индекса окрестностей
+// (см. elastic.GetProximityWindowIndexName): способ по умолчанию tokens в имя не добавляется.
+// Используется и при записи окрестностей, и при поиске, чтобы имена индексов совпадали
+func StrategyIndexInfix(strategy string) string {
+	if strategy == StrategyTokens {
+		return ""
+	}
+
+	return strategy
+}
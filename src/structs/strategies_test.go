@@ -0,0 +1,34 @@
+package structs
+
+import "testing"
+
+func TestIsWindowStrategy(t *testing.T) {
+	for _, strategy := range WindowStrategies {
+		if !IsWindowStrategy(strategy) {
+			t.Errorf("IsWindowStrategy(%q) = false, ожидалось true", strategy)
+		}
+	}
+
+	for _, strategy := range []string{"", "sentences", "Chars"} {
+		if IsWindowStrategy(strategy) {
+			t.Errorf("IsWindowStrategy(%q) = true, ожидалось false", strategy)
+		}
+	}
+}
+
+func TestStrategyIndexInfix(t *testing.T) {
+	tests := []struct {
+		strategy string
+		expected string
+	}{
+		{StrategyTokens, ""},
+		{StrategyWords, StrategyWords},
+		{StrategyChars, StrategyChars},
+	}
+
+	for _, test := range tests {
+		if actual := StrategyIndexInfix(test.strategy); actual != test.expected {
+			t.Errorf("StrategyIndexInfix(%q) = %q, ожидалось %q", test.strategy, actual, test.expected)
+		}
+	}
+}
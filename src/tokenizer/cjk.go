@@ -0,0 +1,140 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const NameCJK = "cjk"
+
+const (
+	scriptOther = iota
+	scriptIdeographic
+	scriptKatakana
+	scriptHangul
+)
+
+// koreanParticles Падежные частицы корейского языка, которые пишутся слитно со словом. Более длинные проверяются первыми
+var koreanParticles = []string{"에서", "으로", "까지", "부터", "보다", "에게", "은", "는", "이", "가", "을", "를", "의", "에", "로", "와", "과", "도", "만"}
+
+// CJKTokenizer Токенизатор для языков без пробелов между словами (китайский, японский) и корейского.
+// Поверх токенизатора по умолчанию разбивает слова:
+// иероглифы и хирагану - по встроенному частотному словарю, катакану и латиницу - целыми последовательностями,
+// корейские слова - с отделением падежных частиц
+type CJKTokenizer struct {
+	base       Tokenizer
+	language   string
+	dictionary *Dictionary
+}
+
+func NewCJKTokenizer(language string) Tokenizer {
+	language = strings.ToLower(language)
+
+	return &CJKTokenizer{
+		base:       NewRegexTokenizer(language),
+		language:   language,
+		dictionary: GetDictionary(language),
+	}
+}
+
+func (t *CJKTokenizer) Tokenize(text string) []Token {
+	var tokens []Token
+
+	for _, current := range t.base.Tokenize(text) {
+		if current.Type != TypeWord {
+			tokens = append(tokens, current)
+			continue
+		}
+
+		start, charStart := current.Start, current.CharStart
+		for _, word := range t.segment(current.Text) {
+			length := utf8.RuneCountInString(word)
+
+			tokens = append(tokens, Token{
+				Type:       TypeWord,
+				Text:       word,
+				Normalized: strings.ToLower(word),
+				Start:      start,
+				End:        start + len(word),
+				CharStart:  charStart,
+				CharEnd:    charStart + length,
+			})
+
+			start += len(word)
+			charStart += length
+		}
+	}
+
+	return tokens
+}
+
+// segment Функция разбивает слово на части по письменностям, а затем каждую часть - по правилам письменности
+func (t *CJKTokenizer) segment(word string) []string {
+	var words []string
+
+	for _, run := range splitScripts(word) {
+		switch scriptOf([]rune(run)[0]) {
+		case scriptIdeographic:
+			words = append(words, t.dictionary.Segment(run)...)
+		case scriptHangul:
+			words = append(words, t.splitParticle(run)...)
+		default:
+			words = append(words, run)
+		}
+	}
+
+	return words
+}
+
+// splitParticle Функция отделяет от корейского слова падежную частицу, если слово без нее не найдено в словаре
+func (t *CJKTokenizer) splitParticle(word string) []string {
+	if t.dictionary.Contains(word) {
+		return []string{word}
+	}
+
+	for _, particle := range koreanParticles {
+		stem := strings.TrimSuffix(word, particle)
+		if stem == word || stem == "" {
+			continue
+		}
+
+		if t.dictionary.Contains(stem) || utf8.RuneCountInString(stem) >= 2 {
+			return []string{stem, particle}
+		}
+	}
+
+	return []string{word}
+}
+
+// splitScripts Функция разбивает слово на непрерывные последовательности одной письменности
+func splitScripts(word string) []string {
+	var runs []string
+
+	start, previous := 0, -1
+	for i, r := range word {
+		current := scriptOf(r)
+		if previous != -1 && current != previous {
+			runs = append(runs, word[start:i])
+			start = i
+		}
+		previous = current
+	}
+
+	return append(runs, word[start:])
+}
+
+// scriptOf Функция определяет письменность символа.
+// Хирагана объединена с иероглифами: она пишется слитно с ними (окончания, частицы) и сегментируется по тому же словарю
+func scriptOf(r rune) int {
+	switch {
+	case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hiragana, r):
+		return scriptIdeographic
+	case unicode.Is(unicode.Katakana, r), r == 'ー':
+		return scriptKatakana
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	default:
+		return scriptOther
+	}
+}
@@ -0,0 +1,64 @@
+package tokenizer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCJKTokenizer(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		words    []string
+	}{
+		{"本发明涉及一种锂离子电池", "zh", []string{"本发明", "涉及", "一种", "锂离子", "电池"}},
+		{"加热温度为20℃，厚度为5mm", "zh", []string{"加热", "温度", "为", "20", "℃", "厚度", "为", "5", "mm"}},
+		{"温度は20℃である", "ja", []string{"温度", "は", "20", "℃", "である"}},
+		{"レーザーの波長は532nm", "ja", []string{"レーザー", "の", "波長", "は", "532", "nm"}},
+		{"온도는 20℃이다", "ko", []string{"온도", "는", "20", "℃", "이다"}},
+		{"두께를 5 mm로 한다", "ko", []string{"두께", "를", "5", "mm", "로", "한다"}},
+	}
+
+	for _, test := range tests {
+		var words []string
+		for _, token := range NewCJKTokenizer(test.language).Tokenize(test.text) {
+			words = append(words, token.Text)
+		}
+
+		if !reflect.DeepEqual(words, test.words) {
+			t.Errorf("Tokenize(%q, %q) = %q, ожидалось %q", test.text, test.language, words, test.words)
+		}
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dict.txt")
+	if err := os.WriteFile(path, []byte("离子 100 n\n电池 80 n\n锂 10\n无频率\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadDictionary("xx", path); err != nil {
+		t.Fatalf("LoadDictionary: %v", err)
+	}
+
+	words := NewCJKTokenizer("xx").Tokenize("锂离子电池")
+	var texts []string
+	for _, token := range words {
+		texts = append(texts, token.Text)
+	}
+
+	if expected := []string{"锂", "离子", "电池"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Tokenize = %q, ожидалось %q", texts, expected)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("без частот\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadDictionary("xx", empty); err == nil {
+		t.Errorf("LoadDictionary(%q) не вернул ошибку для словаря без слов", empty)
+	}
+}
@@ -0,0 +1,176 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed dict/*.txt
+var dictionaryFiles embed.FS
+
+// Dictionary Частотный словарь для сегментации текста без пробелов
+type Dictionary struct {
+	frequencies map[string]float64
+	total       float64
+	maxLength   int
+}
+
+var (
+	dictionariesMx sync.Mutex
+	dictionaries   = map[string]*Dictionary{}
+)
+
+// GetDictionary Функция возвращает словарь языка: загруженный из файла (см. LoadDictionary) или встроенный (zh, ja, ko).
+// Встроенные словари - заглушка из нескольких сотен частых слов и терминов, для реальных текстов нужен полный словарь.
+// Для остальных языков возвращается словарь zh
+func GetDictionary(language string) *Dictionary {
+	dictionariesMx.Lock()
+	defer dictionariesMx.Unlock()
+
+	if dictionary, ok := dictionaries[language]; ok {
+		return dictionary
+	}
+
+	name := language
+	if _, err := fs.Stat(dictionaryFiles, "dict/"+name+".txt"); err != nil {
+		name = "zh"
+	}
+
+	if dictionary, ok := dictionaries[name]; ok {
+		return dictionary
+	}
+
+	data, _ := dictionaryFiles.ReadFile("dict/" + name + ".txt")
+
+	dictionary, _ := readDictionary(bytes.NewReader(data))
+	dictionaries[name] = dictionary
+
+	return dictionary
+}
+
+// LoadDictionary Функция загружает частотный словарь языка из файла и заменяет им встроенный словарь.
+// Словарь должен быть загружен до создания токенизаторов. Формат строки - "слово частота",
+// допускается формат словарей jieba "слово частота тег"
+func LoadDictionary(language string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dictionary, err := readDictionary(file)
+	if err != nil {
+		return err
+	}
+
+	if len(dictionary.frequencies) == 0 {
+		return errors.New("словарь не содержит ни одного слова: " + path)
+	}
+
+	dictionariesMx.Lock()
+	defer dictionariesMx.Unlock()
+
+	dictionaries[language] = dictionary
+
+	return nil
+}
+
+// readDictionary Функция читает частотный словарь. Строки без частоты пропускаются
+func readDictionary(reader io.Reader) (*Dictionary, error) {
+	dictionary := NewDictionary()
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		frequency, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || frequency <= 0 {
+			continue
+		}
+
+		dictionary.Add(fields[0], frequency)
+	}
+
+	return dictionary, scanner.Err()
+}
+
+func NewDictionary() *Dictionary {
+	return &Dictionary{
+		frequencies: map[string]float64{},
+	}
+}
+
+// Add Функция добавляет слово в словарь с абсолютной частотой
+func (d *Dictionary) Add(word string, frequency float64) {
+	d.total += frequency - d.frequencies[word]
+	d.frequencies[word] = frequency
+
+	if length := utf8.RuneCountInString(word); length > d.maxLength {
+		d.maxLength = length
+	}
+}
+
+// Contains Функция проверяет наличие слова в словаре
+func (d *Dictionary) Contains(word string) bool {
+	_, ok := d.frequencies[word]
+
+	return ok
+}
+
+// Segment Функция разбивает строку без пробелов на слова по максимуму суммарной вероятности (униграммная модель).
+// Неизвестные символы становятся отдельными словами
+func (d *Dictionary) Segment(text string) []string {
+	runes := []rune(text)
+	count := len(runes)
+
+	// Вероятность слова - его доля в сумме частот. Неизвестный символ получает штраф,
+	// чтобы словарное слово всегда было выгоднее разбиения на отдельные символы
+	logTotal := math.Log(d.total + 1)
+	unknown := -logTotal - 10
+
+	best := make([]float64, count+1)
+	from := make([]int, count+1)
+	for i := 1; i <= count; i++ {
+		best[i] = math.Inf(-1)
+
+		for length := 1; length <= d.maxLength && length <= i; length++ {
+			var score float64
+			if frequency, ok := d.frequencies[string(runes[i-length:i])]; ok {
+				score = math.Log(frequency) - logTotal
+			} else if length == 1 {
+				score = unknown
+			} else {
+				continue
+			}
+
+			if candidate := best[i-length] + score; candidate > best[i] {
+				best[i] = candidate
+				from[i] = i - length
+			}
+		}
+	}
+
+	var words []string
+	for i := count; i > 0; i = from[i] {
+		words = append(words, string(runes[from[i]:i]))
+	}
+
+	for left, right := 0, len(words)-1; left < right; left, right = left+1, right-1 {
+		words[left], words[right] = words[right], words[left]
+	}
+
+	return words
+}
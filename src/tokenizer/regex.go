@@ -0,0 +1,109 @@
+package tokenizer
+
+import (
+	"elastic-proximity-calculation/src/numbers"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const NameRegex = "regex"
+
+// RegexTokenizer Токенизатор по умолчанию: числа в записи языка (см. numbers.Locale) и последовательности букв.
+// Надстрочные и подстрочные цифры после букв считаются частью слова: m², H₂O.
+// Комбинируемые знаки (огласовки деванагари, диакритика) также считаются частью слова.
+// Символы единиц ℃ и ℉ (не буквы в Unicode) считаются отдельными словами: 20℃
+type RegexTokenizer struct {
+	language string
+	re       *regexp.Regexp
+}
+
+func NewRegexTokenizer(language string) Tokenizer {
+	return &RegexTokenizer{
+		language: language,
+		re:       regexp.MustCompile(`(` + numbers.GetLocale(language).Pattern() + `)|\p{L}[\p{L}\p{M}⁰¹²³⁴⁵⁶⁷⁸⁹₀₁₂₃₄₅₆₇₈₉]*|[℃℉]`),
+	}
+}
+
+func (t *RegexTokenizer) Tokenize(text string) []Token {
+	var tokens []Token
+
+	offset, chars := 0, 0
+	for position := 0; position < len(text); {
+		index := t.re.FindStringSubmatchIndex(text[position:])
+		if index == nil {
+			break
+		}
+
+		start, end := position+index[0], position+index[1]
+		if index[2] >= 0 && isSeparatorDot(text, start) {
+			position = start + 1
+			continue
+		}
+		position = end
+
+		current := Token{Type: TypeWord}
+		if index[2] >= 0 {
+			current.Type = TypeNumber
+
+			if len(tokens) > 0 && !isSign(text, tokens[len(tokens)-1], start) {
+				_, size := utf8.DecodeRuneInString(text[start:])
+				start += size
+			}
+
+		}
+
+		chars += utf8.RuneCountInString(text[offset:start])
+		current.Text = text[start:end]
+		current.Start, current.End = start, end
+		current.CharStart, current.CharEnd = chars, chars+utf8.RuneCountInString(current.Text)
+		current.Normalized = t.normalize(current)
+
+		offset, chars = end, current.CharEnd
+		tokens = append(tokens, current)
+	}
+
+	return tokens
+}
+
+func (t *RegexTokenizer) normalize(current Token) string {
+	if current.Type == TypeNumber {
+		if num, ok := numbers.Parse(current.Text, t.language); ok {
+			return strconv.FormatFloat(num, 'f', -1, 64)
+		}
+
+		return current.Text
+	}
+
+	return strings.ToLower(current.Text)
+}
+
+// isSeparatorDot Функция проверяет, что точка в начале числа - часть многоточия, точка сокращения или разделитель
+// в записи из нескольких чисел через точку, а не десятичный разделитель. В записях "10...20", "рис.2", "12.03.2020" и "1.2.3"
+// число ищется заново со следующего символа, чтобы получились 20, 2, 2020 и 3, а не .20, .2, .2020 и .3
+func isSeparatorDot(text string, start int) bool {
+	if start == 0 || start+1 >= len(text) || text[start] != '.' {
+		return false
+	}
+
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+
+	return before == '.' || unicode.IsLetter(before) || unicode.IsDigit(before)
+}
+
+// isSign Функция проверяет, является ли минус (плюс) в начале числа знаком.
+// В записях "10-20", "10 - 20" и "a-5" это разделитель, а не знак: перед ним стоит слово или число
+func isSign(text string, previous Token, start int) bool {
+	r, _ := utf8.DecodeRuneInString(text[start:])
+	if !strings.ContainsRune("-−+", r) {
+		return true
+	}
+
+	if previous.End == start {
+		return false
+	}
+
+	return !(previous.Type == TypeNumber && strings.TrimSpace(text[previous.End:start]) == "")
+}
@@ -0,0 +1,53 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegexTokenizer(t *testing.T) {
+	tests := []struct {
+		text       string
+		language   string
+		normalized []string
+	}{
+		{"температура -40 °C", "ru", []string{"температура", "-40", "c"}},
+		{"от 10-20 мм", "ru", []string{"от", "10", "20", "мм"}},
+		{"от 10 - 20 мм", "ru", []string{"от", "10", "20", "мм"}},
+		{"step a-5", "en", []string{"step", "a", "5"}},
+		{"rate 1.5e-3 s", "en", []string{"rate", "0.0015", "s"}},
+		{"about 2×10⁻³ mol", "en", []string{"about", "0.002", "mol"}},
+		{"Dichte 1.000,5 kg", "de", []string{"dichte", "1000.5", "kg"}},
+		{"area m² and H₂O", "en", []string{"area", "m²", "and", "h₂o"}},
+		{"см. рис.2 и Fig.3", "ru", []string{"см", "рис", "2", "и", "fig", "3"}},
+		{"from 10...20 and .5 mm", "en", []string{"from", "10", "20", "and", "0.5", "mm"}},
+		{"on 12.03.2020", "en", []string{"on", "12.03", "2020"}},
+		{"от 12.03.2020", "ru", []string{"от", "12.03", "2020"}},
+		{"version 1.2.3", "en", []string{"version", "1.2", "3"}},
+	}
+
+	for _, test := range tests {
+		var normalized []string
+		for _, token := range NewRegexTokenizer(test.language).Tokenize(test.text) {
+			normalized = append(normalized, token.Normalized)
+		}
+
+		if !reflect.DeepEqual(normalized, test.normalized) {
+			t.Errorf("Tokenize(%q) = %q, ожидалось %q", test.text, normalized, test.normalized)
+		}
+	}
+}
+
+func TestRegexTokenizerPositions(t *testing.T) {
+	tokens := NewRegexTokenizer("ru").Tokenize("Длина 1 000 мм")
+
+	expected := []Token{
+		{Type: TypeWord, Text: "Длина", Normalized: "длина", Start: 0, End: 10, CharStart: 0, CharEnd: 5},
+		{Type: TypeNumber, Text: "1 000", Normalized: "1000", Start: 11, End: 16, CharStart: 6, CharEnd: 11},
+		{Type: TypeWord, Text: "мм", Normalized: "мм", Start: 17, End: 21, CharStart: 12, CharEnd: 14},
+	}
+
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Tokenize = %+v, ожидалось %+v", tokens, expected)
+	}
+}
@@ -0,0 +1,92 @@
+package tokenizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	TypeWord   = "word"
+	TypeNumber = "number"
+)
+
+// Token Токен текста
+type Token struct {
+	// Type Тип токена: TypeWord, TypeNumber или собственный тип токенизатора
+	Type string
+	// Text Исходная запись токена
+	Text string
+	// Normalized Нормализованная форма: слова в нижнем регистре, числа в канонической записи
+	Normalized string
+	// Start, End Позиция токена в тексте в байтах
+	Start int
+	End   int
+	// CharStart, CharEnd Позиция токена в тексте в символах (рунах)
+	CharStart int
+	CharEnd   int
+}
+
+// Tokenizer Разбиение текста на токены.
+// Реализация должна быть безопасна для использования из нескольких горутин
+type Tokenizer interface {
+	Tokenize(text string) []Token
+}
+
+// Factory Функция создания токенизатора для конкретного языка
+type Factory func(language string) Tokenizer
+
+var (
+	mx        sync.RWMutex
+	factories = map[string]Factory{
+		NameRegex: NewRegexTokenizer,
+		NameCJK:   NewCJKTokenizer,
+	}
+)
+
+// Register Функция для регистрации собственного токенизатора под именем,
+// которое затем можно указать в параметрах TOKENIZER и LANGUAGE_TOKENIZERS
+func Register(name string, factory Factory) {
+	mx.Lock()
+	defer mx.Unlock()
+
+	factories[name] = factory
+}
+
+// Names Функция возвращает имена всех зарегистрированных токенизаторов
+func Names() []string {
+	mx.RLock()
+	defer mx.RUnlock()
+
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Exists Функция проверяет, зарегистрирован ли токенизатор с таким именем
+func Exists(name string) bool {
+	mx.RLock()
+	defer mx.RUnlock()
+
+	_, ok := factories[name]
+
+	return ok
+}
+
+// New Функция создает токенизатор по имени для конкретного языка
+func New(name string, language string) (Tokenizer, error) {
+	mx.RLock()
+	factory, ok := factories[name]
+	mx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("неизвестный токенизатор: %s (доступны: %s)", name, strings.Join(Names(), ", "))
+	}
+
+	return factory(language), nil
+}
@@ -0,0 +1,60 @@
+package tokenizer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldsTokenizer Токенизатор для тестов: слова по пробелам без разбора чисел
+type fieldsTokenizer struct {
+	language string
+}
+
+func (f fieldsTokenizer) Tokenize(text string) []Token {
+	var tokens []Token
+	for _, field := range strings.Fields(text) {
+		tokens = append(tokens, Token{Type: TypeWord, Text: field, Normalized: f.language + ":" + field})
+	}
+
+	return tokens
+}
+
+func TestRegister(t *testing.T) {
+	defer func() {
+		mx.Lock()
+		delete(factories, "fields")
+		mx.Unlock()
+	}()
+
+	if Exists("fields") {
+		t.Fatalf("Exists(fields) = true до регистрации")
+	}
+
+	Register("fields", func(language string) Tokenizer { return fieldsTokenizer{language: language} })
+
+	if !Exists("fields") || !reflect.DeepEqual(Names(), []string{NameCJK, "fields", NameRegex}) {
+		t.Errorf("Names() = %v после регистрации", Names())
+	}
+
+	created, err := New("fields", "en")
+	if err != nil {
+		t.Fatalf("New(fields) = %v", err)
+	}
+
+	if tokens := created.Tokenize("a 5"); len(tokens) != 2 || tokens[1].Type != TypeWord || tokens[1].Normalized != "en:5" {
+		t.Errorf("Tokenize() = %+v, ожидалось два слова языка en", tokens)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{NameRegex, NameCJK} {
+		if created, err := New(name, "en"); err != nil || created == nil {
+			t.Errorf("New(%s) = %v, %v", name, created, err)
+		}
+	}
+
+	if _, err := New("unknown", "en"); err == nil || !strings.Contains(err.Error(), NameRegex) {
+		t.Errorf("New(unknown) = %v, ожидалась ошибка со списком токенизаторов", err)
+	}
+}
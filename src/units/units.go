@@ -0,0 +1,181 @@
+package units
+
+import (
+	"elastic-proximity-calculation/src/helpers"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	CategoryLength      = "length"
+	CategoryArea        = "area"
+	CategoryVolume      = "volume"
+	CategoryMass        = "mass"
+	CategoryTime        = "time"
+	CategoryTemperature = "temperature"
+	CategoryPressure    = "pressure"
+	CategoryForce       = "force"
+	CategoryEnergy      = "energy"
+	CategoryPower       = "power"
+	CategoryVoltage     = "voltage"
+	CategoryCurrent     = "current"
+	CategoryFrequency   = "frequency"
+	CategoryVelocity    = "velocity"
+	CategoryRatio       = "ratio"
+)
+
+// Unit Единица измерения и правило перевода в СИ: si = value * Factor + Offset
+type Unit struct {
+	Symbol   string
+	Category string
+	Factor   float64
+	Offset   float64
+}
+
+// ToSI Функция переводит значение в базовую единицу СИ категории.
+// Результат округляется до значащих цифр, а не знаков после запятой: 1 нм = 1e-9 м
+func (u Unit) ToSI(value float64) float64 {
+	return helpers.RoundSignificant(value*u.Factor+u.Offset, 12)
+}
+
+// definitions Единицы измерения и все варианты их записи.
+// Обозначения, совпадающие с частыми словами ("in", "A", "t"), намеренно не включены.
+// Обозначение секунды "с" совпадает с предлогом и присоединяется к числу с оговоркой (см. IsAmbiguous)
+var definitions = []struct {
+	unit     Unit
+	variants []string
+}{
+	{Unit{"nm", CategoryLength, 1e-9, 0}, []string{"nm", "нм"}},
+	{Unit{"µm", CategoryLength, 1e-6, 0}, []string{"µm", "μm", "um", "мкм", "micron", "microns"}},
+	{Unit{"mm", CategoryLength, 1e-3, 0}, []string{"mm", "мм"}},
+	{Unit{"cm", CategoryLength, 1e-2, 0}, []string{"cm", "см"}},
+	{Unit{"dm", CategoryLength, 1e-1, 0}, []string{"dm", "дм"}},
+	{Unit{"m", CategoryLength, 1, 0}, []string{"m", "м"}},
+	{Unit{"km", CategoryLength, 1e3, 0}, []string{"km", "км"}},
+	{Unit{"mm²", CategoryArea, 1e-6, 0}, []string{"mm²", "mm2", "мм²", "мм2"}},
+	{Unit{"cm²", CategoryArea, 1e-4, 0}, []string{"cm²", "cm2", "см²", "см2"}},
+	{Unit{"m²", CategoryArea, 1, 0}, []string{"m²", "m2", "м²", "м2"}},
+	{Unit{"mm³", CategoryVolume, 1e-9, 0}, []string{"mm³", "mm3", "мм³", "мм3"}},
+	{Unit{"cm³", CategoryVolume, 1e-6, 0}, []string{"cm³", "cm3", "см³", "см3", "cc"}},
+	{Unit{"m³", CategoryVolume, 1, 0}, []string{"m³", "m3", "м³", "м3"}},
+	{Unit{"ml", CategoryVolume, 1e-6, 0}, []string{"ml", "mL", "мл"}},
+	{Unit{"l", CategoryVolume, 1e-3, 0}, []string{"l", "L", "л"}},
+	{Unit{"µg", CategoryMass, 1e-9, 0}, []string{"µg", "μg", "ug", "мкг"}},
+	{Unit{"mg", CategoryMass, 1e-6, 0}, []string{"mg", "мг"}},
+	{Unit{"g", CategoryMass, 1e-3, 0}, []string{"g", "г"}},
+	{Unit{"kg", CategoryMass, 1, 0}, []string{"kg", "кг"}},
+	{Unit{"ms", CategoryTime, 1e-3, 0}, []string{"ms", "мс"}},
+	{Unit{"s", CategoryTime, 1, 0}, []string{"s", "sec", "с", "сек"}},
+	{Unit{"min", CategoryTime, 60, 0}, []string{"min", "мин"}},
+	{Unit{"h", CategoryTime, 3600, 0}, []string{"h", "hr", "hrs", "hours", "ч", "час", "часа", "часов"}},
+	{Unit{"°C", CategoryTemperature, 1, 273.15}, []string{"°C", "°С", "℃", "ºC", "°c", "°с"}},
+	{Unit{"°F", CategoryTemperature, 5.0 / 9.0, 273.15 - 32*5.0/9.0}, []string{"°F", "℉"}},
+	{Unit{"K", CategoryTemperature, 1, 0}, []string{"K", "К"}},
+	{Unit{"Pa", CategoryPressure, 1, 0}, []string{"Pa", "Па"}},
+	{Unit{"kPa", CategoryPressure, 1e3, 0}, []string{"kPa", "кПа"}},
+	{Unit{"MPa", CategoryPressure, 1e6, 0}, []string{"MPa", "МПа"}},
+	{Unit{"GPa", CategoryPressure, 1e9, 0}, []string{"GPa", "ГПа"}},
+	{Unit{"bar", CategoryPressure, 1e5, 0}, []string{"bar", "бар"}},
+	{Unit{"mbar", CategoryPressure, 1e2, 0}, []string{"mbar", "мбар"}},
+	{Unit{"atm", CategoryPressure, 101325, 0}, []string{"atm", "атм"}},
+	{Unit{"psi", CategoryPressure, 6894.757, 0}, []string{"psi"}},
+	{Unit{"N", CategoryForce, 1, 0}, []string{"N", "Н"}},
+	{Unit{"kN", CategoryForce, 1e3, 0}, []string{"kN", "кН"}},
+	{Unit{"J", CategoryEnergy, 1, 0}, []string{"J", "Дж"}},
+	{Unit{"kJ", CategoryEnergy, 1e3, 0}, []string{"kJ", "кДж"}},
+	{Unit{"W", CategoryPower, 1, 0}, []string{"W", "Вт"}},
+	{Unit{"kW", CategoryPower, 1e3, 0}, []string{"kW", "кВт"}},
+	{Unit{"MW", CategoryPower, 1e6, 0}, []string{"MW", "МВт"}},
+	{Unit{"mV", CategoryVoltage, 1e-3, 0}, []string{"mV", "мВ"}},
+	{Unit{"V", CategoryVoltage, 1, 0}, []string{"V", "В"}},
+	{Unit{"kV", CategoryVoltage, 1e3, 0}, []string{"kV", "кВ"}},
+	{Unit{"mA", CategoryCurrent, 1e-3, 0}, []string{"mA", "мА"}},
+	{Unit{"kA", CategoryCurrent, 1e3, 0}, []string{"kA", "кА"}},
+	{Unit{"Hz", CategoryFrequency, 1, 0}, []string{"Hz", "Гц"}},
+	{Unit{"kHz", CategoryFrequency, 1e3, 0}, []string{"kHz", "кГц"}},
+	{Unit{"MHz", CategoryFrequency, 1e6, 0}, []string{"MHz", "МГц"}},
+	{Unit{"GHz", CategoryFrequency, 1e9, 0}, []string{"GHz", "ГГц"}},
+	{Unit{"rpm", CategoryFrequency, 1.0 / 60, 0}, []string{"rpm", "об/мин"}},
+	{Unit{"m/s", CategoryVelocity, 1, 0}, []string{"m/s", "м/с"}},
+	{Unit{"km/h", CategoryVelocity, 1 / 3.6, 0}, []string{"km/h", "км/ч"}},
+	{Unit{"%", CategoryRatio, 1e-2, 0}, []string{"%", "percent", "процентов", "процента", "процент"}},
+	{Unit{"‰", CategoryRatio, 1e-3, 0}, []string{"‰"}},
+	{Unit{"ppm", CategoryRatio, 1e-6, 0}, []string{"ppm"}},
+}
+
+var (
+	// variants Все варианты записи единиц измерения
+	variants = map[string]Unit{}
+	// orderedVariants Варианты записи по убыванию длины, чтобы "mm" находилось раньше "m"
+	orderedVariants []string
+)
+
+func init() {
+	for _, definition := range definitions {
+		for _, variant := range definition.variants {
+			variants[variant] = definition.unit
+			orderedVariants = append(orderedVariants, variant)
+		}
+	}
+
+	sort.SliceStable(orderedVariants, func(i, j int) bool {
+		return len(orderedVariants[i]) > len(orderedVariants[j])
+	})
+}
+
+// prepositions Однобуквенные обозначения единиц, совпадающие с предлогами: "10 с водой"
+var prepositions = map[string]bool{"с": true}
+
+// IsAmbiguous Функция проверяет, что записанная через пробел единица измерения может оказаться другим словом.
+// Это только однобуквенные обозначения-предлоги, за которыми следует слово: "10 с водой" (но "10 с."),
+// и латинские строчные буквы, за которыми следует точка и буква - сокращения вроде "5 m.p.".
+// after - текст сразу после записи единицы
+func IsAmbiguous(variant string, after string) bool {
+	r, size := utf8.DecodeRuneInString(variant)
+	if size != len(variant) || !unicode.IsLetter(r) {
+		return false
+	}
+
+	if prepositions[variant] {
+		trimmed := strings.TrimLeft(after, " \t\u00a0")
+		next, _ := utf8.DecodeRuneInString(trimmed)
+
+		return len(trimmed) < len(after) && unicode.IsLetter(next)
+	}
+
+	if r < unicode.MaxASCII && unicode.IsLower(r) && strings.HasPrefix(after, ".") {
+		next, _ := utf8.DecodeRuneInString(after[1:])
+
+		return unicode.IsLetter(next)
+	}
+
+	return false
+}
+
+// Get Функция возвращает единицу измерения по одному из вариантов ее записи
+func Get(variant string) (Unit, bool) {
+	unit, ok := variants[variant]
+
+	return unit, ok
+}
+
+// Match Функция ищет единицу измерения в начале текста.
+// Единица должна заканчиваться на границе слова, т.е. "mm" не найдется в "mmHg" или "mmol".
+// Возвращает длину найденной записи в байтах
+func Match(text string) (Unit, int, bool) {
+	for _, variant := range orderedVariants {
+		if len(text) < len(variant) || text[:len(variant)] != variant {
+			continue
+		}
+
+		if next, _ := utf8.DecodeRuneInString(text[len(variant):]); len(text) > len(variant) && (unicode.IsLetter(next) || unicode.IsDigit(next)) {
+			continue
+		}
+
+		return variants[variant], len(variant), true
+	}
+
+	return Unit{}, 0, false
+}
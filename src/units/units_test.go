@@ -0,0 +1,86 @@
+package units
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		text   string
+		symbol string
+		length int
+		ok     bool
+	}{
+		{"mm thick", "mm", 2, true},
+		{"мм", "mm", 4, true},
+		{"mm²", "mm²", 4, true},
+		{"mm2 area", "mm²", 3, true},
+		{"m/s", "m/s", 3, true},
+		{"°C.", "°C", 3, true},
+		{"MPa,", "MPa", 3, true},
+		{"kg", "kg", 2, true},
+		{"mmHg", "", 0, false},
+		{"mmol", "", 0, false},
+		{"meters", "", 0, false},
+		{"", "", 0, false},
+	}
+
+	for _, test := range tests {
+		unit, length, ok := Match(test.text)
+		if ok != test.ok || unit.Symbol != test.symbol || length != test.length {
+			t.Errorf("Match(%q) = %q, %d, %v, ожидалось %q, %d, %v", test.text, unit.Symbol, length, ok, test.symbol, test.length, test.ok)
+		}
+	}
+}
+
+func TestToSI(t *testing.T) {
+	tests := []struct {
+		variant string
+		value   float64
+		si      float64
+	}{
+		{"mm", 5, 0.005},
+		{"нм", 1, 1e-9},
+		{"°C", 100, 373.15},
+		{"°F", 212, 373.15},
+		{"bar", 2, 200000},
+		{"%", 50, 0.5},
+		{"км/ч", 36, 10},
+	}
+
+	for _, test := range tests {
+		unit, ok := Get(test.variant)
+		if !ok {
+			t.Errorf("Get(%q): единица не найдена", test.variant)
+			continue
+		}
+
+		if si := unit.ToSI(test.value); si != test.si {
+			t.Errorf("%v %s в СИ = %v, ожидалось %v", test.value, test.variant, si, test.si)
+		}
+	}
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	tests := []struct {
+		variant   string
+		after     string
+		ambiguous bool
+	}{
+		{"m", "", false},
+		{"г", " муки", false},
+		{"N", " force", false},
+		{"с", "", false},
+		{"с", ".", false},
+		{"с", " водой", true},
+		{"В", " и ток 2 А", false},
+		{"m", ".p.", true},
+		{"m", ". The", false},
+		{"mm", " long", false},
+		{"%", "", false},
+	}
+
+	for _, test := range tests {
+		if ambiguous := IsAmbiguous(test.variant, test.after); ambiguous != test.ambiguous {
+			t.Errorf("IsAmbiguous(%q, %q) = %v, ожидалось %v", test.variant, test.after, ambiguous, test.ambiguous)
+		}
+	}
+}